@@ -1,12 +1,14 @@
 package redmine
 
 import (
+	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/nixys/nxs-go-redmine/v4/mimereader"
 )
@@ -80,7 +82,7 @@ func (r *Context) AttachmentUpload(filePath string) (AttachmentUploadObject, int
 
 	mr := mimereader.New(f)
 
-	status, err := r.uploadFile(mr, &a, ur, http.StatusCreated)
+	status, err := r.uploadFile(mr, &a, ur, http.StatusCreated, http.StatusOK)
 	if err != nil {
 		return a.Upload, status, err
 	}
@@ -102,7 +104,7 @@ func (r *Context) AttachmentUploadStream(f io.Reader, fileName string) (Attachme
 
 	mr := mimereader.New(f)
 
-	status, err := r.uploadFile(mr, &a, ur, http.StatusCreated)
+	status, err := r.uploadFile(mr, &a, ur, http.StatusCreated, http.StatusOK)
 	if err != nil {
 		return a.Upload, status, err
 	}
@@ -140,10 +142,52 @@ func (r *Context) AttachmentDownloadStream(id int) (io.ReadCloser, AttachmentObj
 		return nil, AttachmentObject{}, status, err
 	}
 
-	s, status, err := r.downloadFile(o.ContentURL, http.StatusOK)
+	u, err := r.attachmentURL(o.ContentURL)
+	if err != nil {
+		return nil, AttachmentObject{}, status, err
+	}
+
+	s, status, err := r.downloadFile(u, http.StatusOK)
 	if err != nil {
 		return nil, AttachmentObject{}, status, err
 	}
 
 	return s, o, status, nil
 }
+
+// attachmentURL normalizes attachment content URL: relative URLs are resolved against Redmine endpoint
+// (including its base path) and API key is removed from query (authentication header is used instead)
+func (r *Context) attachmentURL(contentURL string) (string, error) {
+
+	u, err := url.Parse(contentURL)
+	if err != nil {
+		return "", fmt.Errorf("attachment content url parse error: %v", err)
+	}
+
+	if u.IsAbs() == false {
+
+		// Endpoint is stored without trailing slash, it is needed to keep base path for relative references
+		e, err := url.Parse(r.endpoint + "/")
+		if err != nil {
+			return "", fmt.Errorf("endpoint parse error: %v", err)
+		}
+
+		// Root-relative paths are joined with endpoint base path unless they already contain it
+		if strings.HasPrefix(u.Path, "/") == true && strings.HasPrefix(u.Path, e.Path) == false {
+			u.Path = strings.TrimSuffix(e.Path, "/") + u.Path
+			if u.RawPath != "" {
+				u.RawPath = strings.TrimSuffix(e.Path, "/") + u.RawPath
+			}
+		}
+
+		u = e.ResolveReference(u)
+	}
+
+	q := u.Query()
+	if _, b := q["key"]; b == true {
+		q.Del("key")
+		u.RawQuery = q.Encode()
+	}
+
+	return u.String(), nil
+}
@@ -1,6 +1,9 @@
 package redmine
 
 import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strconv"
 	"testing"
@@ -96,3 +99,90 @@ func testAttachmentDownload(t *testing.T, r Context, id int) {
 
 	t.Logf("Attachment get: success")
 }
+
+func TestAttachmentDownloadContentURL(t *testing.T) {
+
+	const (
+		testAPIKey  = "test-api-key"
+		testContent = "test content"
+	)
+
+	var (
+		r          Context
+		base       string
+		contentURL string
+		invalid    []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		switch req.URL.Path {
+		case base + "/attachments/1.json":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"attachment":{"id":1,"filename":"test.txt","content_url":"` + contentURL + `"}}`))
+		case base + "/attachments/download/1/test.txt":
+			if req.URL.Query().Get("key") != "" || req.Header.Get("X-Redmine-API-Key") != testAPIKey {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(testContent))
+		default:
+			invalid = append(invalid, req.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	r.SetAPIKey(testAPIKey)
+
+	for _, e := range []struct {
+		base string
+		urls []string
+	}{
+		{
+			base: "",
+			urls: []string{
+				srv.URL + "/attachments/download/1/test.txt",
+				srv.URL + "/attachments/download/1/test.txt?key=" + testAPIKey,
+				"/attachments/download/1/test.txt",
+				"/attachments/download/1/test.txt?key=" + testAPIKey,
+				"attachments/download/1/test.txt",
+			},
+		},
+		{
+			base: "/redmine",
+			urls: []string{
+				srv.URL + "/redmine/attachments/download/1/test.txt",
+				"/redmine/attachments/download/1/test.txt?key=" + testAPIKey,
+				"/attachments/download/1/test.txt",
+				"attachments/download/1/test.txt",
+			},
+		},
+	} {
+
+		base = e.base
+		r.SetEndpoint(srv.URL + e.base + "/")
+
+		for _, u := range e.urls {
+
+			contentURL = u
+
+			f, _, s, err := r.AttachmentDownloadStream(1)
+			if err != nil {
+				t.Fatalf("Attachment download error: content url `%s` with endpoint base path `%s`: %v (%d, requested: %v)", u, e.base, err, s, invalid)
+			}
+
+			b, err := ioutil.ReadAll(f)
+			f.Close()
+			if err != nil {
+				t.Fatal("Attachment download error:", err)
+			}
+
+			if string(b) != testContent {
+				t.Fatal("Attachment download error: wrong attachment content for content url", u)
+			}
+		}
+	}
+
+	t.Logf("Attachment download content url: success")
+}
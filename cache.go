@@ -0,0 +1,92 @@
+package redmine
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// metadataCache stores rarely changed Redmine data (e.g. project settings, statuses, trackers).
+// It is stored within Context by pointer, so Context copies share the same cache
+type metadataCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]metadataCacheEntry
+}
+
+type metadataCacheEntry struct {
+	value   interface{}
+	status  int
+	expires time.Time
+}
+
+// SetMetadataCache is used to enable metadata cache with specified TTL. Metadata cache is used by helpers
+// requesting rarely changed data (e.g. project time entry activities or trackers).
+// Zero or negative `ttl` disables cache (default)
+func (r *Context) SetMetadataCache(ttl time.Duration) {
+
+	if ttl <= 0 {
+		r.cache = nil
+		return
+	}
+
+	r.cache = &metadataCache{
+		ttl:     ttl,
+		entries: make(map[string]metadataCacheEntry),
+	}
+}
+
+// MetadataCacheFlush drops all cached metadata
+func (r *Context) MetadataCacheFlush() {
+
+	if r.cache == nil {
+		return
+	}
+
+	r.cache.mu.Lock()
+	defer r.cache.mu.Unlock()
+
+	r.cache.entries = make(map[string]metadataCacheEntry)
+}
+
+// cached returns value for specified key from metadata cache if it exists and not expired.
+// Otherwise `get` is called and its result is stored into cache on success
+func (r *Context) cached(key string, get func() (interface{}, int, error)) (interface{}, int, error) {
+
+	if r.cache == nil {
+		return get()
+	}
+
+	r.cache.mu.Lock()
+	e, b := r.cache.entries[key]
+	r.cache.mu.Unlock()
+
+	if b == true && time.Now().Before(e.expires) {
+		return e.value, e.status, nil
+	}
+
+	v, status, err := get()
+	if err != nil {
+		return v, status, err
+	}
+
+	r.cache.mu.Lock()
+	r.cache.entries[key] = metadataCacheEntry{
+		value:   v,
+		status:  status,
+		expires: time.Now().Add(r.cache.ttl),
+	}
+	r.cache.mu.Unlock()
+
+	return v, status, nil
+}
+
+// cacheUserKey returns cache key part identifying user requests are made on behalf of.
+// API key is hashed, so it is not kept in cache keys as is
+func (r *Context) cacheUserKey() string {
+
+	h := sha256.Sum256([]byte(r.apiKeyGet()))
+
+	return r.switchUser + ":" + hex.EncodeToString(h[:])
+}
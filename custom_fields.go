@@ -1,8 +1,10 @@
 package redmine
 
 import (
+	"fmt"
 	"net/http"
 	"net/url"
+	"sort"
 )
 
 /* Get */
@@ -48,6 +50,16 @@ type CustomFieldUpdateObject struct {
 	Value interface{} `json:"value"` // can be a string or strings slice
 }
 
+/* Results */
+
+// CustomFieldDiscrepancyObject stores difference between requested and stored custom field values
+type CustomFieldDiscrepancyObject struct {
+	ID        int
+	Requested []string
+	Stored    []string
+	Missing   bool // custom field is absent in stored values (e.g. it is not enabled for tracker or project)
+}
+
 /* Internal types */
 
 type customFieldAllResult struct {
@@ -69,3 +81,128 @@ func (r *Context) CustomFieldAllGet() ([]CustomFieldObject, int, error) {
 
 	return c.CustomFields, status, err
 }
+
+// CustomFieldNamesGet gets custom fields names by its IDs. Names are got via `CustomFieldAllGet()` (requires admin privileges)
+// with specified language (sent as `Accept-Language` header, language of Context is used if empty), so result does not
+// depend on localization of other responses (e.g. issues custom fields names). Result is stored in metadata cache if it enabled
+func (r *Context) CustomFieldNamesGet(language string) (map[int]string, int, error) {
+
+	c := r
+	if language != "" {
+		c = r.With(WithLanguage(language))
+	}
+
+	v, status, err := c.cached("custom_field_names:"+c.language, func() (interface{}, int, error) {
+
+		fields, s, err := c.CustomFieldAllGet()
+
+		names := make(map[int]string)
+		for _, f := range fields {
+			names[f.ID] = f.Name
+		}
+
+		return names, s, err
+	})
+	if err != nil {
+		return nil, status, err
+	}
+
+	names := make(map[int]string)
+	for id, n := range v.(map[int]string) {
+		names[id] = n
+	}
+
+	return names, status, nil
+}
+
+// CustomFieldsCompare compares requested custom fields values (e.g. used within issue create)
+// with values stored by server (e.g. returned by issue create) and returns discrepancies.
+// Values are compared as strings regardless of order, empty values are considered as equal to absent ones
+func CustomFieldsCompare(requested []CustomFieldUpdateObject, stored []CustomFieldGetObject) []CustomFieldDiscrepancyObject {
+
+	var d []CustomFieldDiscrepancyObject
+
+	s := make(map[int][]string)
+	for _, c := range stored {
+		s[c.ID] = customFieldValuesNormalize(c.Value)
+	}
+
+	for _, c := range requested {
+
+		req := customFieldValuesNormalize(customFieldValueStrings(c.Value))
+
+		st, b := s[c.ID]
+		if b == false {
+			if len(req) > 0 {
+				d = append(d, CustomFieldDiscrepancyObject{
+					ID:        c.ID,
+					Requested: req,
+					Missing:   true,
+				})
+			}
+			continue
+		}
+
+		if customFieldValuesEqual(req, st) == false {
+			d = append(d, CustomFieldDiscrepancyObject{
+				ID:        c.ID,
+				Requested: req,
+				Stored:    st,
+			})
+		}
+	}
+
+	return d
+}
+
+// customFieldValueStrings converts custom field value (a string or strings slice) into strings slice
+func customFieldValueStrings(value interface{}) []string {
+
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case []interface{}:
+		var s []string
+		for _, e := range v {
+			s = append(s, fmt.Sprint(e))
+		}
+		return s
+	default:
+		return []string{fmt.Sprint(v)}
+	}
+}
+
+// customFieldValuesNormalize returns sorted copy of values without empty ones
+func customFieldValuesNormalize(values []string) []string {
+
+	var s []string
+
+	for _, v := range values {
+		if v != "" {
+			s = append(s, v)
+		}
+	}
+
+	sort.Strings(s)
+
+	return s
+}
+
+func customFieldValuesEqual(a, b []string) bool {
+
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
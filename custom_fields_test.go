@@ -1,7 +1,13 @@
 package redmine
 
 import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"testing"
+	"time"
 )
 
 func TestCustomFieldsCRUD(t *testing.T) {
@@ -29,3 +35,125 @@ func testCustomFieldAllGet(t *testing.T, r Context) {
 
 	t.Fatal("Custom fields get error: can't find any custom fields")
 }
+
+func TestCustomFieldNamesGet(t *testing.T) {
+
+	var (
+		r    Context
+		reqs int
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		reqs++
+
+		name := "Severity"
+		if req.Header.Get("Accept-Language") == "de" {
+			name = "Schweregrad"
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"custom_fields":[{"id":3,"name":"` + name + `"}]}`))
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+	r.SetLanguage("de")
+	r.SetMetadataCache(time.Minute)
+
+	for i := 0; i < 2; i++ {
+
+		names, s, err := r.CustomFieldNamesGet("en")
+		if err != nil {
+			t.Fatal("Custom field names get error:", err, s)
+		}
+
+		if names[3] != "Severity" {
+			t.Fatal("Custom field names get error: incorrect name:", names[3])
+		}
+	}
+
+	names, s, err := r.CustomFieldNamesGet("")
+	if err != nil || names[3] != "Schweregrad" {
+		t.Fatal("Custom field names get error: incorrect name for context language:", names[3], err, s)
+	}
+
+	if reqs != 2 {
+		t.Fatal("Custom field names get error: incorrect requests count:", reqs)
+	}
+
+	t.Logf("Custom field names get: success")
+}
+
+func TestCustomFieldsCompare(t *testing.T) {
+
+	var (
+		r    Context
+		sent []byte
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		if req.Method != http.MethodPost || req.URL.Path != "/issues.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		sent, _ = ioutil.ReadAll(req.Body)
+
+		// Field 3 is not enabled for tracker, value of field 2 is not in possible values
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"issue":{"id":10,"custom_fields":[` +
+			`{"id":1,"name":"Tags","multiple":true,"value":["go","api"]},` +
+			`{"id":2,"name":"Team","value":""},` +
+			`{"id":4,"name":"Notes","value":""}]}}`))
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+
+	requested := []CustomFieldUpdateObject{
+		{ID: 1, Value: []string{"api", "go"}},
+		{ID: 2, Value: "mobile"},
+		{ID: 3, Value: "42"},
+		{ID: 4, Value: ""},
+		{ID: 5, Value: nil},
+	}
+
+	i, s, err := r.IssueCreate(IssueCreateObject{
+		ProjectID:    1,
+		Subject:      testIssueSubject,
+		CustomFields: requested,
+	})
+	if err != nil {
+		t.Fatal("Issue create error:", err, s)
+	}
+
+	var body struct {
+		Issue struct {
+			CustomFields []CustomFieldUpdateObject `json:"custom_fields"`
+		} `json:"issue"`
+	}
+
+	if err := json.Unmarshal(sent, &body); err != nil || len(body.Issue.CustomFields) != len(requested) {
+		t.Fatalf("Custom fields compare error: incorrect request body: %s", sent)
+	}
+
+	d := CustomFieldsCompare(requested, i.CustomFields)
+
+	expected := []CustomFieldDiscrepancyObject{
+		{ID: 2, Requested: []string{"mobile"}},
+		{ID: 3, Requested: []string{"42"}, Missing: true},
+	}
+
+	if reflect.DeepEqual(d, expected) == false {
+		t.Fatalf("Custom fields compare error: incorrect discrepancies: %+v", d)
+	}
+
+	if d := CustomFieldsCompare(requested[:1], i.CustomFields); d != nil {
+		t.Fatalf("Custom fields compare error: unexpected discrepancies: %+v", d)
+	}
+
+	t.Logf("Custom fields compare: success")
+}
@@ -0,0 +1,88 @@
+package redmine
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DateFormat is a layout for dates used within Redmine API (e.g. issue start and due dates)
+const DateFormat = "2006-01-02"
+
+// Date is a calendar date used within Redmine API requests (e.g. issue start and due dates).
+// It is marshaled in `DateFormat` layout, zero value is marshaled as empty string (clears date on update)
+type Date struct {
+	t time.Time
+}
+
+// NewDate returns date for specified year, month and day
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{
+		t: time.Date(year, month, day, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// DateParse parses date in `DateFormat` layout (e.g. dates of got issues).
+// Empty string is parsed as zero date
+func DateParse(s string) (Date, error) {
+
+	if s == "" {
+		return Date{}, nil
+	}
+
+	t, err := time.Parse(DateFormat, s)
+	if err != nil {
+		return Date{}, fmt.Errorf("date parse error: incorrect date `%s` (expected format: %s)", s, DateFormat)
+	}
+
+	return Date{t: t}, nil
+}
+
+// Time returns date as a time at midnight UTC
+func (d Date) Time() time.Time {
+	return d.t
+}
+
+// IsZero checks whether date is not set
+func (d Date) IsZero() bool {
+	return d.t.IsZero()
+}
+
+// String returns date in `DateFormat` layout, empty string for zero date
+func (d Date) String() string {
+
+	if d.t.IsZero() == true {
+		return ""
+	}
+
+	return d.t.Format(DateFormat)
+}
+
+// MarshalJSON implements json.Marshaler interface
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (d *Date) UnmarshalJSON(b []byte) error {
+
+	var s *string
+
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("date unmarshal error: %v", err)
+	}
+
+	if s == nil {
+		*d = Date{}
+		return nil
+	}
+
+	v, err := DateParse(*s)
+	if err != nil {
+		return err
+	}
+
+	*d = v
+
+	return nil
+}
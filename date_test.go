@@ -0,0 +1,83 @@
+package redmine
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDateParse(t *testing.T) {
+
+	for _, s := range []string{"2022-13-01", "2022-02-30", "01.07.2022", "2022-7-1", "2022-07-01T10:00:00Z"} {
+		if _, err := DateParse(s); err == nil {
+			t.Fatal("Date parse error: malformed date has been parsed:", s)
+		}
+	}
+
+	d, err := DateParse("2022-07-01")
+	if err != nil || d != NewDate(2022, time.July, 1) {
+		t.Fatal("Date parse error: incorrect date:", d, err)
+	}
+
+	if d, err := DateParse(""); err != nil || d.IsZero() == false {
+		t.Fatal("Date parse error: zero date expected for empty string:", d, err)
+	}
+
+	t.Logf("Date parse: success")
+}
+
+func TestDateJSON(t *testing.T) {
+
+	b, err := json.Marshal(issueUpdate{
+		Issue: IssueUpdateObject{
+			StartDate: &Date{},
+			DueDate:   &testIssueDueDate,
+		},
+	})
+	if err != nil || string(b) != `{"issue":{"start_date":"","due_date":"2022-07-02"}}` {
+		t.Fatal("Date JSON error: incorrect marshaled value:", string(b), err)
+	}
+
+	var d Date
+
+	if err := json.Unmarshal([]byte(`"2022-07-02"`), &d); err != nil || d != testIssueDueDate {
+		t.Fatal("Date JSON error: incorrect unmarshaled value:", d, err)
+	}
+
+	if err := json.Unmarshal([]byte(`"2022-07-32"`), &d); err == nil {
+		t.Fatal("Date JSON error: malformed date has been unmarshaled")
+	}
+
+	t.Logf("Date JSON: success")
+}
+
+func TestIssueDatesValidate(t *testing.T) {
+
+	var r Context
+
+	for _, e := range []struct {
+		start, due *Date
+		valid      bool
+	}{
+		{&testIssueStartDate, &testIssueDueDate, true},
+		{&testIssueStartDate, &testIssueStartDate, true},
+		{&testIssueDueDate, &testIssueStartDate, false},
+		{&testIssueDueDate, &Date{}, true},
+		{nil, &testIssueStartDate, true},
+	} {
+		if err := issueDatesValidate(e.start, e.due); (err == nil) != e.valid {
+			t.Fatalf("Issue dates validate error: incorrect result for dates %v and %v: %v", e.start, e.due, err)
+		}
+	}
+
+	// Request is not sent for invalid dates (context without endpoint would fail otherwise)
+	_, _, err := r.IssueCreate(IssueCreateObject{
+		StartDate: &testIssueDueDate,
+		DueDate:   &testIssueStartDate,
+	})
+	if err == nil || err.Error() != "issue dates validate error: `due_date` (2022-07-01) is earlier than `start_date` (2022-07-02)" {
+		t.Fatal("Issue dates validate error: incorrect error:", err)
+	}
+
+	t.Logf("Issue dates validate: success")
+}
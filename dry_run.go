@@ -0,0 +1,91 @@
+package redmine
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+)
+
+// DryRunOperation contains data of write request captured in dry-run mode
+type DryRunOperation struct {
+	Method string
+	URL    string
+	Body   []byte
+}
+
+// dryRunLog stores operations captured in dry-run mode.
+// It is stored within Context by pointer, so Context copies share the same log
+type dryRunLog struct {
+	mu         sync.Mutex
+	operations []DryRunOperation
+}
+
+// SetDryRun is used to enable or disable dry-run mode. In dry-run mode all write requests (POST, PUT and DELETE,
+// including attachments uploads) are not sent to Redmine but captured (see `DryRunOperations()`) and considered
+// as successful with first of expected statuses (`200` if not specified). Response objects for such requests are not filled,
+// e.g. created objects have zero IDs. Read requests are sent as usual. Every call of this method drops previously captured operations.
+//
+// Helpers making several requests skip steps depending on results of write requests: `IssueCreateWithWatchers()` does not add
+// watchers separately, `IssueImportAs()` does not check created issue author, `IssueSetPrivate()` and `IssueAppendDescription()`
+// do not re-request issue to check update, `MembershipAddMulti()` does not request created memberships,
+// `WikiUpsert()` returns zero page and false created flag
+func (r *Context) SetDryRun(enabled bool) {
+
+	if enabled == false {
+		r.dryRun = nil
+		return
+	}
+
+	r.dryRun = &dryRunLog{}
+}
+
+// DryRunOperations returns operations captured in dry-run mode in order they have been made
+func (r *Context) DryRunOperations() []DryRunOperation {
+
+	if r.dryRun == nil {
+		return nil
+	}
+
+	r.dryRun.mu.Lock()
+	defer r.dryRun.mu.Unlock()
+
+	return append([]DryRunOperation{}, r.dryRun.operations...)
+}
+
+// dryRunEnabled checks whether dry-run mode is enabled
+func (r *Context) dryRunEnabled() bool {
+	return r.dryRun != nil
+}
+
+// dryRunCapture captures write request if dry-run mode is enabled.
+// Returns synthetic status and true if request has been captured
+func (r *Context) dryRunCapture(method, u string, body io.Reader, statusExpected []int) (int, bool, error) {
+
+	if r.dryRun == nil || method == http.MethodGet {
+		return 0, false, nil
+	}
+
+	op := DryRunOperation{
+		Method: method,
+		URL:    u,
+	}
+
+	if body != nil {
+		b, err := ioutil.ReadAll(body)
+		if err != nil {
+			return 0, true, err
+		}
+		op.Body = b
+	}
+
+	r.dryRun.mu.Lock()
+	r.dryRun.operations = append(r.dryRun.operations, op)
+	r.dryRun.mu.Unlock()
+
+	if len(statusExpected) > 0 {
+		return statusExpected[0], true, nil
+	}
+
+	return http.StatusOK, true, nil
+}
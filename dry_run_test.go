@@ -0,0 +1,125 @@
+package redmine
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestDryRun(t *testing.T) {
+
+	var (
+		r      Context
+		writes int
+		reads  int
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		if req.Method != http.MethodGet {
+			writes++
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		reads++
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"issue":{"id":1,"subject":"` + testIssueSubject + `"}}`))
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+	r.SetDryRun(true)
+
+	s, err := r.IssueUpdate(1, IssueUpdateObject{
+		Subject: testIssueSubject2,
+	})
+	if err != nil || s != http.StatusNoContent {
+		t.Fatal("Dry run error: synthetic success expected, got:", err, s)
+	}
+
+	s, err = r.IssueDelete(1)
+	if err != nil {
+		t.Fatal("Dry run error: synthetic success expected, got:", err, s)
+	}
+
+	i, s, err := r.IssueSingleGet(1, IssueSingleGetRequest{})
+	if err != nil || i.Subject != testIssueSubject {
+		t.Fatal("Dry run error: read request failed:", err, s)
+	}
+
+	ops := r.DryRunOperations()
+	if writes != 0 || reads != 1 || len(ops) != 2 {
+		t.Fatalf("Dry run error: incorrect requests (writes: %d, reads: %d, captured: %d)", writes, reads, len(ops))
+	}
+
+	if ops[0].Method != http.MethodPut || ops[0].URL != srv.URL+"/issues/1.json" || string(ops[0].Body) != `{"issue":{"subject":"`+testIssueSubject2+`"}}` {
+		t.Fatalf("Dry run error: incorrect captured operation: %s %s %s", ops[0].Method, ops[0].URL, ops[0].Body)
+	}
+
+	if ops[1].Method != http.MethodDelete {
+		t.Fatal("Dry run error: incorrect captured operation method:", ops[1].Method)
+	}
+
+	t.Logf("Dry run: success")
+}
+
+func TestDryRunHelpers(t *testing.T) {
+
+	var (
+		r      Context
+		writes int
+		reads  []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		if req.Method != http.MethodGet {
+			writes++
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		reads = append(reads, req.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"issue":{"id":1,"description":"text","is_private":0}}`))
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+	r.SetDryRun(true)
+
+	if _, errs, s, err := r.IssueCreateWithWatchers(IssueCreateObject{ProjectID: 1, Subject: testIssueSubject}, []int{3, 5}); err != nil || errs != nil {
+		t.Fatal("Dry run error: issue create with watchers failed:", err, errs, s)
+	}
+
+	if s, err := r.IssueSetPrivate(1, true); err != nil {
+		t.Fatal("Dry run error: issue set private failed:", err, s)
+	}
+
+	if s, err := r.IssueAppendDescription(1, IssueAppendDescriptionRequest{Text: "appended"}); err != nil {
+		t.Fatal("Dry run error: issue append description failed:", err, s)
+	}
+
+	if _, created, s, err := r.WikiUpsert("test", "Page", "text", ""); err != nil || created == true {
+		t.Fatal("Dry run error: wiki upsert failed:", err, created, s)
+	}
+
+	// First expected status is used if specified, `200` otherwise
+	if s, err := r.Put(nil, nil, url.URL{Path: "/issues/1.json"}); err != nil || s != http.StatusOK {
+		t.Fatal("Dry run error: synthetic success expected, got:", err, s)
+	}
+
+	if writes != 0 || len(r.DryRunOperations()) != 5 {
+		t.Fatalf("Dry run error: incorrect requests (writes: %d, captured: %d)", writes, len(r.DryRunOperations()))
+	}
+
+	if len(reads) != 1 || reads[0] != "/issues/1.json" {
+		t.Fatal("Dry run error: incorrect read requests:", reads)
+	}
+
+	t.Logf("Dry run helpers: success")
+}
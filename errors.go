@@ -0,0 +1,132 @@
+package redmine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Error is returned if Redmine API responds with unexpected status code.
+// Also it is used for some errors detected by helpers on client side, `Method` and `URL`
+// are empty in this case and `StatusCode` contains status the error corresponds to
+type Error struct {
+	StatusCode     int
+	StatusExpected []int
+	Method         string
+	URL            string
+	Errors         []string // errors returned by Redmine (or response body decode errors)
+}
+
+// Error returns error text
+func (e *Error) Error() string {
+
+	if e.Method == "" {
+		return strings.Join(e.Errors, "\n")
+	}
+
+	status := fmt.Sprintf("unexpected status code has been returned (expected: %s, returned: %d, url: %s, method: %s)", statusesString(e.StatusExpected), e.StatusCode, e.URL, e.Method)
+
+	if len(e.Errors) == 0 {
+		return status
+	}
+
+	return strings.Join(e.Errors, "\n") + "\n" + status
+}
+
+// ErrNotApplied is matched (via `errors.Is()`) by errors returned if Redmine has accepted request
+// but silently ignored requested change (e.g. due to insufficient permissions)
+var ErrNotApplied = errors.New("change has not been applied")
+
+// ErrVersionConflict is matched (via `errors.Is()`) by errors caused by update of stale object version
+// (e.g. wiki page updated with outdated `Version`), see `VersionConflictError`
+var ErrVersionConflict = errors.New("version conflict")
+
+// VersionConflictError is returned if Redmine responds with `409 Conflict` to update of stale object version.
+// It wraps original `*Error`, so `IsConflict()` is also true for it
+type VersionConflictError struct {
+	CurrentVersion int // current object version on server, 0 if it could not be got
+	Err            error
+}
+
+// Error returns error text
+func (e *VersionConflictError) Error() string {
+
+	if e.CurrentVersion == 0 {
+		return fmt.Sprintf("version conflict: %v", e.Err)
+	}
+
+	return fmt.Sprintf("version conflict (current version: %d): %v", e.CurrentVersion, e.Err)
+}
+
+// Unwrap returns original error
+func (e *VersionConflictError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is `ErrVersionConflict`
+func (e *VersionConflictError) Is(target error) bool {
+	return target == ErrVersionConflict
+}
+
+// IsVersionConflict checks whether error is caused by update of stale object version
+func IsVersionConflict(err error) bool {
+	return errors.Is(err, ErrVersionConflict)
+}
+
+// IsNotFound checks whether error is caused by `404 Not Found` response
+func IsNotFound(err error) bool {
+	return errorStatus(err) == http.StatusNotFound
+}
+
+// IsForbidden checks whether error is caused by `403 Forbidden` response
+// (e.g. insufficient permissions or disabled project module)
+func IsForbidden(err error) bool {
+	return errorStatus(err) == http.StatusForbidden
+}
+
+// IsUnauthorized checks whether error is caused by `401 Unauthorized` response
+func IsUnauthorized(err error) bool {
+	return errorStatus(err) == http.StatusUnauthorized
+}
+
+// IsConflict checks whether error is caused by `409 Conflict` response
+func IsConflict(err error) bool {
+	return errorStatus(err) == http.StatusConflict
+}
+
+// IsUnprocessable checks whether error is caused by `422 Unprocessable Entity` response (e.g. validation errors)
+func IsUnprocessable(err error) bool {
+	return errorStatus(err) == http.StatusUnprocessableEntity
+}
+
+func errorStatus(err error) int {
+
+	var e *Error
+
+	if errors.As(err, &e) == false {
+		return 0
+	}
+
+	return e.StatusCode
+}
+
+// responseError makes an error for response with unexpected status code
+func responseError(res *http.Response, body io.Reader, statusExpected []int) error {
+
+	var er errorsResult
+
+	if err := json.NewDecoder(body).Decode(&er); err != nil {
+		er.Errors = append(er.Errors, fmt.Sprintf("json decode error: %v", err))
+	}
+
+	return &Error{
+		StatusCode:     res.StatusCode,
+		StatusExpected: statusExpected,
+		Method:         res.Request.Method,
+		URL:            res.Request.URL.String(),
+		Errors:         er.Errors,
+	}
+}
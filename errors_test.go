@@ -0,0 +1,47 @@
+package redmine
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestErrorText(t *testing.T) {
+
+	errs := make([]string, 1, 4)
+	errs[0] = "Subject cannot be blank"
+
+	e := &Error{
+		StatusCode:     http.StatusUnprocessableEntity,
+		StatusExpected: []int{http.StatusCreated},
+		Method:         http.MethodPost,
+		URL:            "https://redmine.example.com/issues.json",
+		Errors:         errs,
+	}
+
+	expected := "Subject cannot be blank\nunexpected status code has been returned (expected: 201, returned: 422, url: https://redmine.example.com/issues.json, method: POST)"
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if s := e.Error(); s != expected {
+				t.Errorf("Error text error: incorrect text: %s", s)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if len(e.Errors) != 1 || errs[:2][1] != "" {
+		t.Fatal("Error text error: errors list has been changed:", errs[:2])
+	}
+
+	if s := (&Error{StatusCode: http.StatusNotFound, Method: http.MethodGet, URL: "/"}).Error(); s != "unexpected status code has been returned (expected: 2xx, returned: 404, url: /, method: GET)" {
+		t.Fatal("Error text error: incorrect text without errors:", s)
+	}
+
+	t.Logf("Error text: success")
+}
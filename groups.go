@@ -88,38 +88,32 @@ type groupUpdate struct {
 // see: http://www.redmine.org/projects/redmine/wiki/Rest_Groups#GET
 func (r *Context) GroupAllGet() (GroupResult, int, error) {
 
-	var (
-		groups         GroupResult
-		offset, status int
-	)
+	var groups GroupResult
 
-	m := GroupMultiGetRequest{
-		Limit: limitDefault,
-	}
-
-	for {
-
-		m.Offset = offset
+	m := GroupMultiGetRequest{}
 
-		g, s, err := r.GroupMultiGet(m)
-		if err != nil {
-			return groups, s, err
-		}
+	pages, total, status, err := r.paginate(func(offset, limit int) (interface{}, int, int, int, error) {
 
-		status = s
+		req := m
+		req.Offset = offset
+		req.Limit = limit
 
-		groups.Groups = append(groups.Groups, g.Groups...)
+		p, s, err := r.GroupMultiGet(req)
 
-		if offset+g.Limit >= g.TotalCount {
-			groups.TotalCount = g.TotalCount
-			groups.Limit = g.TotalCount
+		return p.Groups, len(p.Groups), p.TotalCount, s, err
+	})
 
-			break
-		}
+	for _, p := range pages {
+		groups.Groups = append(groups.Groups, p.([]GroupObject)...)
+	}
 
-		offset += g.Limit
+	if err != nil {
+		return groups, status, err
 	}
 
+	groups.TotalCount = total
+	groups.Limit = total
+
 	return groups, status, nil
 }
 
@@ -141,6 +135,11 @@ func (r *Context) GroupMultiGet(request GroupMultiGetRequest) (GroupResult, int,
 
 	s, err := r.Get(&g, ur, http.StatusOK)
 
+	// Redmine returns empty list if offset is beyond total count
+	if err == nil && g.Groups == nil {
+		g.Groups = []GroupObject{}
+	}
+
 	return g, s, err
 }
 
@@ -155,6 +154,10 @@ func (r *Context) GroupSingleGet(id int, request GroupSingleGetRequest) (GroupOb
 
 	var g groupSingleResult
 
+	if err := r.includesValidate("group", request.Includes); err != nil {
+		return g.Group, 0, err
+	}
+
 	urlParams := url.Values{}
 
 	// Preparing includes
@@ -181,7 +184,7 @@ func (r *Context) GroupCreate(group GroupCreateObject) (GroupObject, int, error)
 		Path: "/groups.json",
 	}
 
-	status, err := r.Post(groupCreate{Group: group}, &g, ur, http.StatusCreated)
+	status, err := r.Post(groupCreate{Group: group}, &g, ur, http.StatusCreated, http.StatusOK)
 
 	return g.Group, status, err
 }
@@ -195,7 +198,7 @@ func (r *Context) GroupUpdate(id int, group GroupUpdateObject) (int, error) {
 		Path: "/groups/" + strconv.Itoa(id) + ".json",
 	}
 
-	status, err := r.Put(groupUpdate{Group: group}, nil, ur, http.StatusNoContent)
+	status, err := r.Put(groupUpdate{Group: group}, nil, ur, http.StatusNoContent, http.StatusOK)
 
 	return status, err
 }
@@ -209,7 +212,7 @@ func (r *Context) GroupDelete(id int) (int, error) {
 		Path: "/groups/" + strconv.Itoa(id) + ".json",
 	}
 
-	status, err := r.Del(nil, nil, ur, http.StatusNoContent)
+	status, err := r.Del(nil, nil, ur, http.StatusNoContent, http.StatusOK)
 
 	return status, err
 }
@@ -223,7 +226,7 @@ func (r *Context) GroupAddUser(id int, group GroupAddUserObject) (int, error) {
 		Path: "/groups/" + strconv.Itoa(id) + "/users.json",
 	}
 
-	status, err := r.Post(group, nil, ur, http.StatusNoContent)
+	status, err := r.Post(group, nil, ur, http.StatusNoContent, http.StatusOK)
 
 	return status, err
 }
@@ -237,7 +240,7 @@ func (r *Context) GroupDeleteUser(id int, userID int) (int, error) {
 		Path: "/groups/" + strconv.Itoa(id) + "/users/" + strconv.Itoa(userID) + ".json",
 	}
 
-	status, err := r.Del(nil, nil, ur, http.StatusNoContent)
+	status, err := r.Del(nil, nil, ur, http.StatusNoContent, http.StatusOK)
 
 	return status, err
 }
@@ -0,0 +1,141 @@
+package redmine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Includes available for API methods with Redmine versions they are supported since.
+// Empty version means include is supported by all Redmine versions
+var includesAvailable = map[string]map[string]string{
+	"issues": {
+		"attachments": "3.4.0",
+		"relations":   "",
+		"journals":    "",
+		"children":    "",
+	},
+	"issue": {
+		"children":         "",
+		"attachments":      "",
+		"relations":        "",
+		"changesets":       "",
+		"journals":         "",
+		"watchers":         "2.3.0",
+		"allowed_statuses": "5.0.0",
+	},
+	"project": {
+		"trackers":              "",
+		"issue_categories":      "",
+		"enabled_modules":       "2.6.0",
+		"time_entry_activities": "3.4.0",
+		"issue_custom_fields":   "4.2.0",
+	},
+	"user": {
+		"groups":      "",
+		"memberships": "",
+	},
+	"group": {
+		"users":       "",
+		"memberships": "",
+	},
+	"wiki": {
+		"attachments": "",
+	},
+}
+
+// SetServerVersion is used to set Redmine server version (e.g. `4.2.3`) for strict includes mode
+// and to check issues filters not supported by older servers (see `IssueGetRequestFilters`).
+// Redmine API does not provide server version, so it must be specified manually
+func (r *Context) SetServerVersion(version string) error {
+
+	if _, err := versionParse(version); err != nil {
+		return err
+	}
+
+	r.serverVersion = version
+
+	return nil
+}
+
+// SetStrictIncludes is used to enable strict includes mode. In this mode requested includes are
+// checked before request: unknown includes or includes not supported by server version (if specified
+// via `SetServerVersion()`) cause an error instead of being silently ignored by Redmine
+func (r *Context) SetStrictIncludes(strict bool) {
+	r.strictIncludes = strict
+}
+
+// includesValidate checks includes for specified resource in strict includes mode
+func (r *Context) includesValidate(resource string, includes []string) error {
+
+	if r.strictIncludes == false {
+		return nil
+	}
+
+	available := includesAvailable[resource]
+
+	for _, i := range includes {
+
+		since, b := available[i]
+		if b == false {
+			return fmt.Errorf("includes validate error: include `%s` is not available for %s", i, resource)
+		}
+
+		if since == "" || r.serverVersion == "" {
+			continue
+		}
+
+		if versionCompare(r.serverVersion, since) < 0 {
+			return fmt.Errorf("includes validate error: include `%s` for %s requires Redmine %s or later (server version: %s)", i, resource, since, r.serverVersion)
+		}
+	}
+
+	return nil
+}
+
+// versionParse parses version string like `4.2.3` into numbers
+func versionParse(version string) ([]int, error) {
+
+	var v []int
+
+	for _, p := range strings.Split(version, ".") {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("version parse error: incorrect version `%s`", version)
+		}
+		v = append(v, n)
+	}
+
+	return v, nil
+}
+
+// versionCompare compares two versions. Returns -1 if `a` < `b`, 1 if `a` > `b` and 0 if versions are equal.
+// Versions must be valid (see `versionParse()`)
+func versionCompare(a, b string) int {
+
+	va, _ := versionParse(a)
+	vb, _ := versionParse(b)
+
+	for i := 0; i < len(va) || i < len(vb); i++ {
+
+		var x, y int
+
+		if i < len(va) {
+			x = va[i]
+		}
+
+		if i < len(vb) {
+			y = vb[i]
+		}
+
+		if x < y {
+			return -1
+		}
+
+		if x > y {
+			return 1
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,47 @@
+package redmine
+
+import (
+	"testing"
+)
+
+func TestIncludesValidate(t *testing.T) {
+
+	var r Context
+
+	// Non-strict mode: everything is passed to server as is
+	if err := r.includesValidate("issue", []string{"allowed_statuses", "unknown"}); err != nil {
+		t.Fatal("Includes validate error:", err)
+	}
+
+	r.SetStrictIncludes(true)
+
+	if err := r.SetServerVersion("4.2.3"); err != nil {
+		t.Fatal("Includes validate error:", err)
+	}
+
+	if err := r.includesValidate("issue", []string{"journals", "watchers"}); err != nil {
+		t.Fatal("Includes validate error:", err)
+	}
+
+	if err := r.includesValidate("issue", []string{"allowed_statuses"}); err == nil {
+		t.Fatal("Includes validate error: include not supported by server version has been passed")
+	}
+
+	if err := r.includesValidate("issue", []string{"unknown"}); err == nil {
+		t.Fatal("Includes validate error: unknown include has been passed")
+	}
+
+	if err := r.SetServerVersion("5.0"); err != nil {
+		t.Fatal("Includes validate error:", err)
+	}
+
+	if err := r.includesValidate("issue", []string{"allowed_statuses"}); err != nil {
+		t.Fatal("Includes validate error:", err)
+	}
+
+	if err := r.SetServerVersion("5.x"); err == nil {
+		t.Fatal("Includes validate error: incorrect server version has been accepted")
+	}
+
+	t.Logf("Includes validate: success")
+}
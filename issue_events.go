@@ -0,0 +1,263 @@
+package redmine
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// IssueEventType defines issue event type
+type IssueEventType string
+
+// IssueEventType const
+const (
+	IssueEventNoteAdded         IssueEventType = "note_added"
+	IssueEventFieldChanged      IssueEventType = "field_changed"
+	IssueEventAttachmentAdded   IssueEventType = "attachment_added"
+	IssueEventAttachmentDeleted IssueEventType = "attachment_deleted"
+)
+
+/* Results */
+
+// IssueEventObject stores single event of issue audit trail
+type IssueEventObject struct {
+	Type         IssueEventType
+	JournalID    int
+	User         IDName
+	CreatedOn    string
+	Notes        string // used only: note added
+	PrivateNotes bool   // used only: note added
+
+	// Journal detail for field changed and attachment events. `Property` is one of `attr`, `cf`,
+	// `attachment` or `relation`, `Name` is attribute name (e.g. `status_id`), custom field ID or attachment ID
+	Property string
+	Name     string
+	OldValue string
+	NewValue string
+
+	// Human-readable values for `status_id`, `tracker_id`, `priority_id` and `assigned_to_id` attributes
+	// (empty if value could not be resolved)
+	OldValueName string
+	NewValueName string
+}
+
+// IssueEventsGet gets audit trail for issue with specified ID as a flat chronological events stream.
+// Every journal is split into note added event (if it has notes) and events for every detail.
+// Statuses, trackers, priorities and assignees names are stored in metadata cache if it enabled. Assignees are
+// resolved as users first and as groups if there is no such user. Journals of private notes not visible for current user are absent
+func (r *Context) IssueEventsGet(id int) ([]IssueEventObject, int, error) {
+
+	events := []IssueEventObject{}
+
+	i, status, err := r.IssueSingleGet(id, IssueSingleGetRequest{
+		Includes: []string{"journals"},
+	})
+	if err != nil {
+		return events, status, err
+	}
+
+	for _, j := range i.Journals {
+
+		e := IssueEventObject{
+			JournalID: j.ID,
+			User:      j.User,
+			CreatedOn: j.CreatedOn,
+		}
+
+		if j.Notes != "" {
+			n := e
+			n.Type = IssueEventNoteAdded
+			n.Notes = j.Notes
+			n.PrivateNotes = j.PrivateNotes
+			events = append(events, n)
+		}
+
+		for _, d := range j.Details {
+
+			c := e
+			c.Property = d.Property
+			c.Name = d.Name
+			c.OldValue = d.OldValue
+			c.NewValue = d.NewValue
+
+			switch {
+			case d.Property == "attachment" && d.NewValue != "":
+				c.Type = IssueEventAttachmentAdded
+			case d.Property == "attachment":
+				c.Type = IssueEventAttachmentDeleted
+			default:
+				c.Type = IssueEventFieldChanged
+			}
+
+			events = append(events, c)
+		}
+	}
+
+	names, s, err := r.issueEventsNames(events)
+	if err != nil {
+		return events, s, err
+	}
+
+	for n, e := range events {
+		if e.Type != IssueEventFieldChanged || e.Property != "attr" {
+			continue
+		}
+		events[n].OldValueName = names[e.Name][e.OldValue]
+		events[n].NewValueName = names[e.Name][e.NewValue]
+	}
+
+	sort.SliceStable(events, func(a, b int) bool {
+		return events[a].CreatedOn < events[b].CreatedOn
+	})
+
+	return events, status, nil
+}
+
+// issueEventsNames gets names for attributes values used within specified events.
+// Returns map of attribute names to map of values to its names
+func (r *Context) issueEventsNames(events []IssueEventObject) (map[string]map[string]string, int, error) {
+
+	var assigneeIDs []int
+
+	names := make(map[string]map[string]string)
+	seen := make(map[int]bool)
+	status := 0
+
+	for _, e := range events {
+
+		if e.Type != IssueEventFieldChanged || e.Property != "attr" {
+			continue
+		}
+
+		switch e.Name {
+		case "status_id", "tracker_id", "priority_id":
+			names[e.Name] = nil
+		case "assigned_to_id":
+			names[e.Name] = nil
+			for _, v := range []string{e.OldValue, e.NewValue} {
+				if id, err := strconv.Atoi(v); err == nil && id != 0 && seen[id] == false {
+					seen[id] = true
+					assigneeIDs = append(assigneeIDs, id)
+				}
+			}
+		}
+	}
+
+	for attr := range names {
+
+		if attr == "assigned_to_id" {
+			continue
+		}
+
+		v, s, err := r.cached("issue_event_names:"+attr, func() (interface{}, int, error) {
+
+			m := make(map[string]string)
+
+			switch attr {
+			case "status_id":
+				statuses, s, err := r.IssueStatusAllGet()
+				for _, e := range statuses {
+					m[strconv.Itoa(e.ID)] = e.Name
+				}
+				return m, s, err
+			case "tracker_id":
+				trackers, s, err := r.TrackerAllGet()
+				for _, e := range trackers {
+					m[strconv.Itoa(e.ID)] = e.Name
+				}
+				return m, s, err
+			case "priority_id":
+				priorities, s, err := r.EnumerationPrioritiesAllGet()
+				for _, e := range priorities {
+					m[strconv.Itoa(e.ID)] = e.Name
+				}
+				return m, s, err
+			}
+
+			return m, 0, nil
+		})
+		if err != nil {
+			return names, s, err
+		}
+
+		names[attr] = v.(map[string]string)
+		status = s
+	}
+
+	if _, b := names["assigned_to_id"]; b == true {
+
+		m, s, err := r.issueEventsAssignees(assigneeIDs)
+		if err != nil {
+			return names, s, err
+		}
+
+		names["assigned_to_id"] = m
+	}
+
+	return names, status, nil
+}
+
+// issueEventsAssignees gets names for assignees with specified IDs simultaneously.
+// Returns map of IDs to names, assignees which could not be resolved are absent
+func (r *Context) issueEventsAssignees(ids []int) (map[string]string, int, error) {
+
+	type nameResult struct {
+		name   string
+		status int
+		err    error
+	}
+
+	results := make([]nameResult, len(ids))
+
+	parallel(len(ids), 0, func(n int) {
+
+		v, s, err := r.cached("issue_event_names:assigned_to_id:"+strconv.Itoa(ids[n]), func() (interface{}, int, error) {
+			return r.issueEventsAssignee(ids[n])
+		})
+		if err != nil {
+			results[n] = nameResult{status: s, err: err}
+			return
+		}
+
+		results[n] = nameResult{name: v.(string), status: s}
+	})
+
+	m := make(map[string]string)
+
+	for n, e := range results {
+
+		if e.err != nil {
+			return m, e.status, e.err
+		}
+
+		if e.name != "" {
+			m[strconv.Itoa(ids[n])] = e.name
+		}
+	}
+
+	return m, http.StatusOK, nil
+}
+
+// issueEventsAssignee gets name for assignee with specified ID. Issues may be assigned to groups, so if there is
+// no visible user with such ID, group is requested. Empty name is returned if neither of them is visible
+func (r *Context) issueEventsAssignee(id int) (interface{}, int, error) {
+
+	u, s, err := r.UserSingleGet(id, UserSingleGetRequest{})
+	if err == nil {
+		return strings.TrimSpace(u.FirstName + " " + u.LastName), s, nil
+	}
+	if IsNotFound(err) == false && IsForbidden(err) == false {
+		return "", s, err
+	}
+
+	g, s, err := r.GroupSingleGet(id, GroupSingleGetRequest{})
+	if err == nil {
+		return g.Name, s, nil
+	}
+	if IsNotFound(err) == false && IsForbidden(err) == false {
+		return "", s, err
+	}
+
+	return "", s, nil
+}
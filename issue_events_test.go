@@ -0,0 +1,140 @@
+package redmine
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestIssueEventsGet(t *testing.T) {
+
+	var r Context
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch req.URL.Path {
+		case "/issues/1.json":
+			w.Write([]byte(`{"issue":{"id":1,"journals":[` +
+				`{"id":11,"user":{"id":5,"name":"John Smith"},"notes":"Done","created_on":"2022-07-02T10:00:00Z","details":[` +
+				`{"property":"attr","name":"status_id","old_value":"1","new_value":"5"},` +
+				`{"property":"attr","name":"assigned_to_id","old_value":"5","new_value":"6"}]},` +
+				`{"id":10,"user":{"id":5,"name":"John Smith"},"notes":"","created_on":"2022-07-01T10:00:00Z","details":[` +
+				`{"property":"attachment","name":"7","new_value":"log.txt"}]}]}}`))
+		case "/issue_statuses.json":
+			w.Write([]byte(`{"issue_statuses":[{"id":1,"name":"New"},{"id":5,"name":"Closed","is_closed":true}]}`))
+		case "/users/5.json":
+			w.Write([]byte(`{"user":{"id":5,"firstname":"John","lastname":"Smith"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+
+	events, s, err := r.IssueEventsGet(1)
+	if err != nil {
+		t.Fatal("Issue events get error:", err, s)
+	}
+
+	if len(events) != 4 {
+		t.Fatal("Issue events get error: incorrect events count:", len(events))
+	}
+
+	if events[0].Type != IssueEventAttachmentAdded || events[0].JournalID != 10 {
+		t.Fatalf("Issue events get error: incorrect first event: %+v", events[0])
+	}
+
+	if events[1].Type != IssueEventNoteAdded || events[1].Notes != "Done" {
+		t.Fatalf("Issue events get error: incorrect note event: %+v", events[1])
+	}
+
+	if events[2].Type != IssueEventFieldChanged || events[2].OldValueName != "New" || events[2].NewValueName != "Closed" {
+		t.Fatalf("Issue events get error: incorrect status event: %+v", events[2])
+	}
+
+	// User 6 is not visible
+	if events[3].OldValueName != "John Smith" || events[3].NewValueName != "" || events[3].NewValue != "6" {
+		t.Fatalf("Issue events get error: incorrect assignee event: %+v", events[3])
+	}
+
+	t.Logf("Issue events get: success")
+}
+
+func TestIssueEventsGetAssignees(t *testing.T) {
+
+	var (
+		r    Context
+		mu   sync.Mutex
+		reqs = make(map[string]int)
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		mu.Lock()
+		reqs[req.URL.Path]++
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch req.URL.Path {
+		case "/issues/1.json":
+			w.Write([]byte(`{"issue":{"id":1,"journals":[` +
+				`{"id":10,"user":{"id":5,"name":"John Smith"},"created_on":"2022-07-01T10:00:00Z","details":[` +
+				`{"property":"attr","name":"assigned_to_id","old_value":"5","new_value":"9"}]},` +
+				`{"id":11,"user":{"id":5,"name":"John Smith"},"created_on":"2022-07-02T10:00:00Z","details":[` +
+				`{"property":"attr","name":"assigned_to_id","old_value":"9","new_value":"7"}]}]}}`))
+		case "/users/5.json":
+			w.Write([]byte(`{"user":{"id":5,"firstname":"John","lastname":"Smith"}}`))
+		case "/groups/9.json":
+			w.Write([]byte(`{"group":{"id":9,"name":"Developers"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+	r.SetMetadataCache(time.Minute)
+
+	for i := 0; i < 2; i++ {
+
+		events, s, err := r.IssueEventsGet(1)
+		if err != nil {
+			t.Fatal("Issue events get error:", err, s)
+		}
+
+		if len(events) != 2 {
+			t.Fatal("Issue events get error: incorrect events count:", len(events))
+		}
+
+		if events[0].OldValueName != "John Smith" || events[0].NewValueName != "Developers" {
+			t.Fatalf("Issue events get error: incorrect assignee event: %+v", events[0])
+		}
+
+		// Neither user nor group 7 is visible
+		if events[1].OldValueName != "Developers" || events[1].NewValueName != "" {
+			t.Fatalf("Issue events get error: incorrect assignee event: %+v", events[1])
+		}
+	}
+
+	if reqs["/issues/1.json"] != 2 {
+		t.Fatal("Issue events get error: incorrect issue requests count:", reqs["/issues/1.json"])
+	}
+
+	for _, p := range []string{"/users/5.json", "/users/9.json", "/groups/9.json", "/users/7.json", "/groups/7.json"} {
+		if reqs[p] != 1 {
+			t.Fatalf("Issue events get error: assignee has not been cached (%s requests: %d)", p, reqs[p])
+		}
+	}
+
+	if reqs["/groups/5.json"] != 0 {
+		t.Fatal("Issue events get error: group has been requested for resolved user")
+	}
+
+	t.Logf("Issue events get assignees: success")
+}
@@ -0,0 +1,130 @@
+package redmine
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+/* Get */
+
+// IssueRelationResolvedObject struct used for issue relations get operations
+type IssueRelationResolvedObject struct {
+	IssueRelationObject
+
+	// Issue is a related issue info. It is nil if resolution has not been requested,
+	// resolution limit has been exceeded or issue is not visible for current user
+	Issue *IssueRelationIssueObject
+}
+
+// IssueRelationIssueObject struct used for issue relations get operations
+type IssueRelationIssueObject struct {
+	ID      int
+	Subject string
+	Tracker IDName
+	Status  IDName
+}
+
+/* Requests */
+
+// IssueRelationsAllGetRequest contains data for making request to get issue relations
+type IssueRelationsAllGetRequest struct {
+
+	// Resolve enables resolution of related issues (subject, tracker and status)
+	Resolve bool
+
+	// ResolveLimit is a max number of related issues to resolve (100 by default)
+	ResolveLimit int
+}
+
+/* Internal types */
+
+type issueRelationsAllResult struct {
+	Relations []IssueRelationObject `json:"relations"`
+}
+
+// IssueRelationsAllGet gets all relations for issue with specified ID.
+// If resolution is requested related issues are got in batches via `IssuesByIDs()`.
+// Related issue for every relation is the one at the other end of relation,
+// i.e. `issue_id` for relations pointed to specified issue and `issue_to_id` otherwise
+//
+// see: https://www.redmine.org/projects/redmine/wiki/Rest_IssueRelations#GET
+func (r *Context) IssueRelationsAllGet(issueID int, request IssueRelationsAllGetRequest) ([]IssueRelationResolvedObject, int, error) {
+
+	var i issueRelationsAllResult
+
+	ur := url.URL{
+		Path: "/issues/" + strconv.Itoa(issueID) + "/relations.json",
+	}
+
+	status, err := r.Get(&i, ur, http.StatusOK)
+	if err != nil {
+		return nil, status, err
+	}
+
+	relations := []IssueRelationResolvedObject{}
+	for _, rel := range i.Relations {
+		relations = append(relations, IssueRelationResolvedObject{
+			IssueRelationObject: rel,
+		})
+	}
+
+	if request.Resolve == false || len(relations) == 0 {
+		return relations, status, nil
+	}
+
+	limit := request.ResolveLimit
+	if limit <= 0 {
+		limit = limitDefault
+	}
+
+	var ids []int
+
+	uniq := make(map[int]bool)
+	for _, rel := range i.Relations {
+
+		id := issueRelationTarget(issueID, rel)
+
+		if uniq[id] == true {
+			continue
+		}
+
+		if len(ids) >= limit {
+			break
+		}
+
+		uniq[id] = true
+		ids = append(ids, id)
+	}
+
+	issues, s, err := r.IssuesByIDs(ids, nil)
+	if err != nil {
+		return relations, s, err
+	}
+
+	resolved := make(map[int]*IssueRelationIssueObject)
+	for _, issue := range issues {
+		resolved[issue.ID] = &IssueRelationIssueObject{
+			ID:      issue.ID,
+			Subject: issue.Subject,
+			Tracker: issue.Tracker,
+			Status:  issue.Status,
+		}
+	}
+
+	for n := range relations {
+		relations[n].Issue = resolved[issueRelationTarget(issueID, relations[n].IssueRelationObject)]
+	}
+
+	return relations, status, nil
+}
+
+// issueRelationTarget returns ID of issue at the other end of relation
+func issueRelationTarget(issueID int, rel IssueRelationObject) int {
+
+	if rel.IssueToID == issueID {
+		return rel.IssueID
+	}
+
+	return rel.IssueToID
+}
@@ -0,0 +1,67 @@
+package redmine
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIssueRelationsResolve(t *testing.T) {
+
+	var (
+		r          Context
+		issuesReqs int
+		resolved   []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch req.URL.Path {
+		case "/issues/10/relations.json":
+			w.Write([]byte(`{"relations":[` +
+				`{"id":1,"issue_id":10,"issue_to_id":11,"relation_type":"blocked"},` +
+				`{"id":2,"issue_id":12,"issue_to_id":10,"relation_type":"blocks"},` +
+				`{"id":3,"issue_id":10,"issue_to_id":11,"relation_type":"relates"},` +
+				`{"id":4,"issue_id":10,"issue_to_id":13,"relation_type":"relates"}]}`))
+		case "/issues.json":
+			issuesReqs++
+			resolved = append(resolved, req.URL.Query().Get("issue_id"))
+			w.Write([]byte(`{"issues":[` +
+				`{"id":11,"subject":"Eleven","tracker":{"id":1,"name":"Bug"},"status":{"id":2,"name":"New"}},` +
+				`{"id":12,"subject":"Twelve","tracker":{"id":1,"name":"Bug"},"status":{"id":3,"name":"Closed"}}],` +
+				`"total_count":2,"offset":0,"limit":100}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+
+	rels, s, err := r.IssueRelationsAllGet(10, IssueRelationsAllGetRequest{
+		Resolve:      true,
+		ResolveLimit: 2,
+	})
+	if err != nil {
+		t.Fatal("Issue relations get error:", err, s)
+	}
+
+	if len(rels) != 4 || issuesReqs != 1 {
+		t.Fatalf("Issue relations get error: incorrect result (relations: %d, issues requests: %d)", len(rels), issuesReqs)
+	}
+
+	if resolved[0] != "11,12" {
+		t.Fatal("Issue relations error: incorrect issues to resolve:", resolved[0])
+	}
+
+	if rels[0].Issue == nil || rels[0].Issue.Subject != "Eleven" ||
+		rels[1].Issue == nil || rels[1].Issue.Status.Name != "Closed" ||
+		rels[2].Issue == nil || rels[2].Issue.ID != 11 ||
+		rels[3].Issue != nil {
+		t.Fatal("Issue relations get error: relations resolved incorrectly")
+	}
+
+	t.Logf("Issue relations resolve: success")
+}
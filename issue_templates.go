@@ -0,0 +1,154 @@
+package redmine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Issue template defaults
+const (
+	IssueTemplateDelimiterDefault = "---"
+	IssueTemplateSeparatorDefault = ":"
+)
+
+// IssueTemplateConfig contains settings for issue templates parsing.
+//
+// Template is a text with front matter block and body, e.g.:
+//
+//	---
+//	subject: Release preparation
+//	tracker_id: 2
+//	priority_id: 4
+//	cf_5: backend
+//	---
+//	Issue description
+//
+// Front matter block is enclosed by `Delimiter` lines and contains `key: value` lines (`Separator`
+// is used between key and value). Empty lines and lines started with `#` are skipped. Available keys:
+// `subject`, `tracker_id`, `status_id`, `priority_id`, `category_id`, `fixed_version_id`,
+// `assigned_to_id`, `estimated_hours`, `is_private` and `cf_<ID>` for custom fields values.
+// Template body is used as issue description. Text without front matter block is used as description entirely
+type IssueTemplateConfig struct {
+	Delimiter string // `IssueTemplateDelimiterDefault` if empty
+	Separator string // `IssueTemplateSeparatorDefault` if empty
+}
+
+// IssueTemplateGet gets wiki page with specified project ID and title and parses it as an issue template.
+// Project ID of resulting object is not set, it (as well as other fields if needed) must be filled before create
+//
+// See `IssueTemplateConfig` for template format
+func (r *Context) IssueTemplateGet(projectID, wikiTitle string, config IssueTemplateConfig) (IssueCreateObject, int, error) {
+
+	w, status, err := r.WikiSingleGet(projectID, wikiTitle, WikiSingleGetRequest{})
+	if err != nil {
+		return IssueCreateObject{}, status, err
+	}
+
+	i, err := IssueTemplateParse(w.Text, config)
+
+	return i, status, err
+}
+
+// IssueTemplateParse parses specified text as an issue template
+//
+// See `IssueTemplateConfig` for template format
+func IssueTemplateParse(text string, config IssueTemplateConfig) (IssueCreateObject, error) {
+
+	var i IssueCreateObject
+
+	if config.Delimiter == "" {
+		config.Delimiter = IssueTemplateDelimiterDefault
+	}
+
+	if config.Separator == "" {
+		config.Separator = IssueTemplateSeparatorDefault
+	}
+
+	lines := strings.Split(strings.Replace(text, "\r\n", "\n", -1), "\n")
+
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != config.Delimiter {
+		i.Description = text
+		return i, nil
+	}
+
+	end := -1
+	for n := 1; n < len(lines); n++ {
+		if strings.TrimSpace(lines[n]) == config.Delimiter {
+			end = n
+			break
+		}
+	}
+
+	if end < 0 {
+		return i, fmt.Errorf("issue template parse error: front matter closing delimiter `%s` not found", config.Delimiter)
+	}
+
+	for n, l := range lines[1:end] {
+
+		l = strings.TrimSpace(l)
+
+		if l == "" || strings.HasPrefix(l, "#") {
+			continue
+		}
+
+		kv := strings.SplitN(l, config.Separator, 2)
+		if len(kv) != 2 {
+			return i, fmt.Errorf("issue template parse error: incorrect front matter line %d: %s", n+2, l)
+		}
+
+		if err := issueTemplateField(&i, strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])); err != nil {
+			return i, fmt.Errorf("issue template parse error: line %d: %v", n+2, err)
+		}
+	}
+
+	i.Description = strings.Join(lines[end+1:], "\n")
+
+	return i, nil
+}
+
+func issueTemplateField(i *IssueCreateObject, key, value string) error {
+
+	var err error
+
+	switch key {
+	case "subject":
+		i.Subject = value
+	case "tracker_id":
+		i.TrackerID, err = strconv.Atoi(value)
+	case "status_id":
+		i.StatusID, err = strconv.Atoi(value)
+	case "priority_id":
+		i.PriorityID, err = strconv.Atoi(value)
+	case "category_id":
+		i.CategoryID, err = strconv.Atoi(value)
+	case "fixed_version_id":
+		i.FixedVersionID, err = strconv.Atoi(value)
+	case "assigned_to_id":
+		i.AssignedToID, err = strconv.Atoi(value)
+	case "estimated_hours":
+		i.EstimatedHours, err = strconv.ParseFloat(value, 64)
+	case "is_private":
+		i.IsPrivate, err = strconv.ParseBool(value)
+	default:
+		if strings.HasPrefix(key, "cf_") == false {
+			return fmt.Errorf("unknown key `%s`", key)
+		}
+
+		id, e := strconv.Atoi(strings.TrimPrefix(key, "cf_"))
+		if e != nil {
+			return fmt.Errorf("incorrect custom field key `%s`", key)
+		}
+
+		i.CustomFields = append(i.CustomFields, CustomFieldUpdateObject{
+			ID:    id,
+			Value: value,
+		})
+	}
+
+	if err != nil {
+		return fmt.Errorf("incorrect `%s` value: %s", key, value)
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package redmine
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIssueTemplateGet(t *testing.T) {
+
+	var r Context
+
+	pages := map[string]string{
+		"/projects/test/wiki/Release.json": "---\r\n" +
+			"subject: Release preparation\r\n" +
+			"# comment\r\n" +
+			"\r\n" +
+			"tracker_id: 2\r\n" +
+			"priority_id: 4\r\n" +
+			"estimated_hours: 1.5\r\n" +
+			"is_private: true\r\n" +
+			"cf_5: backend: api\r\n" +
+			"---\r\n" +
+			"Issue description\r\n" +
+			"Second line",
+		"/projects/test/wiki/Plain.json":   "Just a description",
+		"/projects/test/wiki/Broken.json":  "---\nsubject: Broken\n",
+		"/projects/test/wiki/Unknown.json": "---\nsubject: Unknown\nowner: jsmith\n---\n",
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		text, b := pages[req.URL.Path]
+		if b == false {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		body, _ := json.Marshal(map[string]interface{}{
+			"wiki_page": map[string]interface{}{
+				"title":   "Page",
+				"text":    text,
+				"version": 1,
+			},
+		})
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+
+	i, s, err := r.IssueTemplateGet("test", "Release", IssueTemplateConfig{})
+	if err != nil {
+		t.Fatal("Issue template get error:", err, s)
+	}
+
+	if i.Subject != "Release preparation" || i.TrackerID != 2 || i.PriorityID != 4 || i.EstimatedHours != 1.5 || i.IsPrivate != true {
+		t.Fatalf("Issue template get error: incorrect issue fields: %+v", i)
+	}
+
+	if len(i.CustomFields) != 1 || i.CustomFields[0].ID != 5 || i.CustomFields[0].Value != "backend: api" {
+		t.Fatalf("Issue template get error: incorrect custom fields: %+v", i.CustomFields)
+	}
+
+	if i.Description != "Issue description\nSecond line" || i.ProjectID != 0 {
+		t.Fatalf("Issue template get error: incorrect description: %q", i.Description)
+	}
+
+	i, s, err = r.IssueTemplateGet("test", "Plain", IssueTemplateConfig{})
+	if err != nil || i.Description != "Just a description" || i.Subject != "" {
+		t.Fatalf("Issue template get error: incorrect template without front matter: %+v (%v, %d)", i, err, s)
+	}
+
+	for _, p := range []string{"Broken", "Unknown"} {
+		if _, _, err := r.IssueTemplateGet("test", p, IssueTemplateConfig{}); err == nil {
+			t.Fatal("Issue template get error: parse error expected for page", p)
+		}
+	}
+
+	if _, _, err := r.IssueTemplateGet("test", "Absent", IssueTemplateConfig{}); IsNotFound(err) == false {
+		t.Fatal("Issue template get error: not found error expected:", err)
+	}
+
+	t.Logf("Issue template get: success")
+}
+
+func TestIssueTemplateParseConfig(t *testing.T) {
+
+	i, err := IssueTemplateParse("+++\nsubject = Custom\nassigned_to_id = 7\n+++\nBody", IssueTemplateConfig{
+		Delimiter: "+++",
+		Separator: "=",
+	})
+	if err != nil {
+		t.Fatal("Issue template parse error:", err)
+	}
+
+	if i.Subject != "Custom" || i.AssignedToID != 7 || i.Description != "Body" {
+		t.Fatalf("Issue template parse error: incorrect issue: %+v", i)
+	}
+
+	if _, err := IssueTemplateParse("---\ntracker_id: bug\n---\n", IssueTemplateConfig{}); err == nil {
+		t.Fatal("Issue template parse error: incorrect value has been accepted")
+	}
+
+	t.Logf("Issue template parse with custom config: success")
+}
@@ -1,44 +1,74 @@
 package redmine
 
 import (
+	"fmt"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
+)
+
+const (
+	issueAppendDescriptionAttempts = 3
+	issuesBulkDeleteMaxDefault     = 50
+	issueFiltersUpdatedBySince     = "3.4.0"
+	issueTotalEstimatedHoursSince  = "3.3.0"
+)
+
+// Special issue filters values
+const (
+	IssueFilterMe   = "me" // matches current user (for user fields, e.g. `assigned_to_id` or user format custom fields)
+	IssueFilterAny  = "*"  // matches issues with any value set
+	IssueFilterNone = "!*" // matches issues without value
+)
+
+// IssueWatchingState defines issue watching state type
+type IssueWatchingState int
+
+// IssueWatchingState const
+const (
+	IssueWatchingStateUnknown     IssueWatchingState = 0
+	IssueWatchingStateWatching    IssueWatchingState = 1
+	IssueWatchingStateNotWatching IssueWatchingState = 2
 )
 
 /* Get */
 
 // IssueObject struct used for issues get operations
 type IssueObject struct {
-	ID             int                    `json:"id"`
-	Project        IDName                 `json:"project"`
-	Tracker        IDName                 `json:"tracker"`
-	Status         IDName                 `json:"status"`
-	Priority       IDName                 `json:"priority"`
-	Author         IDName                 `json:"author"`
-	AssignedTo     IDName                 `json:"assigned_to"`
-	Category       IDName                 `json:"category"`
-	FixedVersion   IDName                 `json:"fixed_version"`
-	Parent         IssueParentObject      `json:"parent"`
-	Subject        string                 `json:"subject"`
-	Description    string                 `json:"description"`
-	StartDate      string                 `json:"start_date"`
-	DueDate        string                 `json:"due_date"`
-	DoneRatio      int                    `json:"done_ratio"`
-	IsPrivate      int                    `json:"is_private"`
-	EstimatedHours float64                `json:"estimated_hours"`
-	SpentHours     float64                `json:"spent_hours"` // used only: get single issue
-	CustomFields   []CustomFieldGetObject `json:"custom_fields"`
-	CreatedOn      string                 `json:"created_on"`
-	UpdatedOn      string                 `json:"updated_on"`
-	ClosedOn       string                 `json:"closed_on"`
-	Children       []IssueChildrenObject  `json:"children"`
-	Attachments    []AttachmentObject     `json:"attachments"` // used only: get single issue
-	Relations      []IssueRelationObject  `json:"relations"`
-	Changesets     []IssueChangesetObject `json:"changesets"` // used only: get single issue
-	Journals       []IssueJournalObject   `json:"journals"`   // used only: get single issue
-	Watchers       []IDName               `json:"watchers"`   // used only: get single issue
+	ID                  int                    `json:"id"`
+	Project             IDName                 `json:"project"`
+	Tracker             IDName                 `json:"tracker"`
+	Status              IDName                 `json:"status"`
+	Priority            IDName                 `json:"priority"`
+	Author              IDName                 `json:"author"`
+	AssignedTo          IDName                 `json:"assigned_to"`
+	Category            IDName                 `json:"category"`
+	FixedVersion        IDName                 `json:"fixed_version"`
+	Parent              IssueParentObject      `json:"parent"`
+	Subject             string                 `json:"subject"`
+	Description         string                 `json:"description"`
+	StartDate           string                 `json:"start_date"` // see `DateParse()`
+	DueDate             string                 `json:"due_date"`   // see `DateParse()`
+	DoneRatio           int                    `json:"done_ratio"`
+	IsPrivate           int                    `json:"is_private"` // 1 for private issues
+	EstimatedHours      float64                `json:"estimated_hours"`
+	SpentHours          float64                `json:"spent_hours"`           // used only: get single issue
+	TotalEstimatedHours *float64               `json:"total_estimated_hours"` // including subtasks, since 3.3.0 (nil if not provided)
+	TotalSpentHours     *float64               `json:"total_spent_hours"`     // including subtasks, since 3.3.0, used only: get single issue (nil if not provided)
+	CustomFields        []CustomFieldGetObject `json:"custom_fields"`
+	CreatedOn           string                 `json:"created_on"`
+	UpdatedOn           string                 `json:"updated_on"`
+	ClosedOn            string                 `json:"closed_on"` // empty for issues that have never been closed
+	Children            []IssueChildrenObject  `json:"children"`
+	Attachments         []AttachmentObject     `json:"attachments"` // used only: get single issue
+	Relations           []IssueRelationObject  `json:"relations"`
+	Changesets          []IssueChangesetObject `json:"changesets"`       // used only: get single issue
+	Journals            []IssueJournalObject   `json:"journals"`         // used only: get single issue
+	Watchers            []IDName               `json:"watchers"`         // used only: get single issue
+	AllowedStatuses     []IDName               `json:"allowed_statuses"` // used only: get single issue, since 5.0.0
 }
 
 // IssueParentObject struct used for issues get operations
@@ -99,8 +129,8 @@ type IssueCreateObject struct {
 	PriorityID     int                       `json:"priority_id,omitempty"`
 	Subject        string                    `json:"subject"`
 	Description    string                    `json:"description,omitempty"`
-	StartDate      string                    `json:"start_date,omitempty"`
-	DueDate        string                    `json:"due_date,omitempty"`
+	StartDate      *Date                     `json:"start_date,omitempty"` // server default is used if not set
+	DueDate        *Date                     `json:"due_date,omitempty"`
 	CategoryID     int                       `json:"category_id,omitempty"`
 	FixedVersionID int                       `json:"fixed_version_id,omitempty"`
 	AssignedToID   int                       `json:"assigned_to_id,omitempty"`
@@ -122,8 +152,8 @@ type IssueUpdateObject struct {
 	PriorityID     int                       `json:"priority_id,omitempty"`
 	Subject        string                    `json:"subject,omitempty"`
 	Description    string                    `json:"description,omitempty"`
-	StartDate      *string                   `json:"start_date,omitempty"`
-	DueDate        *string                   `json:"due_date,omitempty"`
+	StartDate      *Date                     `json:"start_date,omitempty"` // not changed if nil, zero date clears it
+	DueDate        *Date                     `json:"due_date,omitempty"`   // not changed if nil, zero date clears it
 	CategoryID     int                       `json:"category_id,omitempty"`
 	FixedVersionID int                       `json:"fixed_version_id,omitempty"`
 	AssignedToID   int                       `json:"assigned_to_id,omitempty"`
@@ -161,16 +191,96 @@ type IssueSingleGetRequest struct {
 type IssueGetRequestFilters struct {
 	Fields map[string][]string
 	Cf     []IssueGetRequestFiltersCf
+
+	// UpdatedByID filters issues updated by user with specified ID (or `IssueFilterMe`) at least once,
+	// i.e. user is an author of any issue journal. Sent as `updated_by` filter
+	UpdatedByID string
+
+	// LastUpdatedByID filters issues last updated by user with specified ID (or `IssueFilterMe`),
+	// i.e. user is an author of the latest issue journal. Sent as `last_updated_by` filter
+	LastUpdatedByID string
+
+	// Note: `updated_by` and `last_updated_by` filters are supported since Redmine 3.4.0. Older servers
+	// ignore them and return unfiltered result, so if server version is set (see `SetServerVersion()`)
+	// requests with these filters to older servers return an error
+
+	// ProjectIDs filters issues belonging to any of specified projects (nil value disables filter,
+	// empty slice matches no issues), see `IssueFiltersMyProjects()`. Redmine does not accept several
+	// projects within short filters syntax, so if it is set all filters are sent in extended syntax
+	// (`f[]`, `op[field]` and `v[field][]`). Operators from values prefixes (e.g. "!", "~", "><") are recognized
+	// the same way as Redmine does for short filters, except relative dates operators. Open issues
+	// filter is added if `status_id` is not specified, like Redmine does by default. It can not be used
+	// along with `project_id` field filter
+	ProjectIDs []int
 }
 
-// IssueGetRequestFiltersCf contains data for making issues get request
+// IssueGetRequestFiltersCf contains data for making issues get request.
+// Value is passed as is, valid values depend on custom field format:
+// * user: user ID, `IssueFilterMe`
+// * version: version ID
+// * list, key/value list: possible value (list) or its ID (key/value list)
+// * string, text, link: "~" prefix for contains matching
+// * all formats: `IssueFilterAny`, `IssueFilterNone`, "!" prefix for negation and "|" to separate several values
 type IssueGetRequestFiltersCf struct {
 	ID    int
 	Value string
 }
 
+// IssueAppendDescriptionRequest contains data for making request to append text to issue description
+type IssueAppendDescriptionRequest struct {
+	Text      string
+	Separator string // separator between current description and appended text, "\n\n" if empty
+	Marker    string // if set and current description contains it, text is not appended
+}
+
+// IssueJournalAuthorGetRequest contains data for making request to get issues with notes added by specified user
+type IssueJournalAuthorGetRequest struct {
+	UserID      int
+	From        time.Time              // first date of range (time of day is ignored), zero value means range is not bounded
+	To          time.Time              // last date of range (included, time of day is ignored), zero value means range is not bounded
+	Filters     IssueGetRequestFilters // additional filters for candidate issues
+	Concurrency int                    // max number of issues journals requested simultaneously, default value used if zero
+}
+
+// IssuesBulkDeleteRequest contains data for making request to delete several issues
+type IssuesBulkDeleteRequest struct {
+
+	// MaxCount is a safety cap: deletion of more issues is refused unless `Force` is set (50 by default)
+	MaxCount int
+	Force    bool
+
+	// Concurrency is a max number of issues deleted simultaneously, default value used if zero.
+	// It is reduced while Redmine responds with `429 Too Many Requests` and restored when throttling subsides.
+	// Use retry policy with `429` status (see `SetRetryPolicy()`) to retry throttled issues
+	Concurrency int
+
+	// DescendantsCheck is called before deletion if set. Redmine deletes subtasks of deleted issues,
+	// so descendants of specified issues are counted (issues are requested with `children` include)
+	// and passed to the func. Deletion proceeds only if it returns true
+	DescendantsCheck func(ids []int, descendants []int) bool
+}
+
 /* Results */
 
+// IssuesBulkDeleteResult stores issues bulk delete processing result
+type IssuesBulkDeleteResult struct {
+	Deleted     []int         // deleted issues IDs (including issues already deleted with its parents)
+	Descendants []int         // descendants of specified issues not specified themselves, filled only if descendants check has been set
+	Errors      map[int]error // errors for issues could not be deleted
+}
+
+// IssueJournalAuthorObject stores issue with notes added by specified user
+type IssueJournalAuthorObject struct {
+	Issue    IssueObject
+	Journals []IssueJournalObject
+}
+
+// IssueUsersObject stores issue with resolved users referenced within it (see `IssueSingleGetWithUsers()`)
+type IssueUsersObject struct {
+	Issue IssueObject
+	Users map[int]UserObject
+}
+
 // IssueResult stores issues requests processing result
 type IssueResult struct {
 	Issues     []IssueObject `json:"issues"`
@@ -179,12 +289,28 @@ type IssueResult struct {
 	Limit      int           `json:"limit"`
 }
 
+// IssueDoneRatioResult stores issue done ratio rollup result
+type IssueDoneRatioResult struct {
+	DoneRatio  float64 // computed done ratio (0-100)
+	SampleSize int     // leaf subtasks count used for computation, 0 if issue has no subtasks
+}
+
+// IssueEstimatedHoursResult stores issue estimated hours
+type IssueEstimatedHoursResult struct {
+	Own   float64 // issue own estimated hours
+	Total float64 // issue and all its subtasks estimated hours
+}
+
 /* Internal types */
 
 type issueSingleResult struct {
 	Issue IssueObject `json:"issue"`
 }
 
+type issueCountResult struct {
+	TotalCount int `json:"total_count"`
+}
+
 type issueCreate struct {
 	Issue IssueCreateObject `json:"issue"`
 }
@@ -193,10 +319,63 @@ type issueUpdate struct {
 	Issue IssueUpdateObject `json:"issue"`
 }
 
+type issueSetPrivate struct {
+	Issue issueSetPrivateObject `json:"issue"`
+}
+
+type issueSetPrivateObject struct {
+	IsPrivate bool `json:"is_private"`
+}
+
 type issueWatcherAdd struct {
 	UserID int `json:"user_id"`
 }
 
+func (s IssueWatchingState) String() string {
+
+	state := map[IssueWatchingState]string{
+		IssueWatchingStateUnknown:     "unknown",
+		IssueWatchingStateWatching:    "watching",
+		IssueWatchingStateNotWatching: "not watching",
+	}
+
+	v, b := state[s]
+	if b == false {
+		return "unknown"
+	}
+
+	return v
+}
+
+// CycleTime returns the duration between issue creation and closing.
+// Second returned value is false if issue is not closed or timestamps can not be parsed
+func (i IssueObject) CycleTime() (time.Duration, bool) {
+
+	if i.CreatedOn == "" || i.ClosedOn == "" {
+		return 0, false
+	}
+
+	c, err := time.Parse(time.RFC3339, i.CreatedOn)
+	if err != nil {
+		return 0, false
+	}
+
+	d, err := time.Parse(time.RFC3339, i.ClosedOn)
+	if err != nil {
+		return 0, false
+	}
+
+	return d.Sub(c), true
+}
+
+// SetIssueTrackerCheck is used to enable check of issue tracker before issue create.
+// If enabled `IssueCreate()` returns an error if specified tracker is not enabled for issue project
+// instead of sending request and getting `422` status from server. Project trackers are stored
+// in metadata cache if it enabled
+func (r *Context) SetIssueTrackerCheck(check bool) {
+	r.issueTrackerCheck = check
+}
+
 // IssuesAllGet gets info for all issues satisfying specified filters
 //
 // see: http://www.redmine.org/projects/redmine/wiki/Rest_Issues#Listing-issues
@@ -208,40 +387,35 @@ type issueWatcherAdd struct {
 // * children
 func (r *Context) IssuesAllGet(request IssueAllGetRequest) (IssueResult, int, error) {
 
-	var (
-		issues         IssueResult
-		offset, status int
-	)
+	var issues IssueResult
 
 	m := IssueMultiGetRequest{
 		Filters:  request.Filters,
 		Includes: request.Includes,
-		Limit:    limitDefault,
 	}
 
-	for {
+	pages, total, status, err := r.paginate(func(offset, limit int) (interface{}, int, int, int, error) {
 
-		m.Offset = offset
+		req := m
+		req.Offset = offset
+		req.Limit = limit
 
-		i, s, err := r.IssuesMultiGet(m)
-		if err != nil {
-			return issues, s, err
-		}
+		p, s, err := r.IssuesMultiGet(req)
 
-		status = s
+		return p.Issues, len(p.Issues), p.TotalCount, s, err
+	})
 
-		issues.Issues = append(issues.Issues, i.Issues...)
-
-		if offset+i.Limit >= i.TotalCount {
-			issues.TotalCount = i.TotalCount
-			issues.Limit = i.TotalCount
-
-			break
-		}
+	for _, p := range pages {
+		issues.Issues = append(issues.Issues, p.([]IssueObject)...)
+	}
 
-		offset += i.Limit
+	if err != nil {
+		return issues, status, err
 	}
 
+	issues.TotalCount = total
+	issues.Limit = total
+
 	return issues, status, nil
 }
 
@@ -258,26 +432,115 @@ func (r *Context) IssuesMultiGet(request IssueMultiGetRequest) (IssueResult, int
 
 	var i IssueResult
 
-	urlParams := url.Values{}
-	urlParams.Add("offset", strconv.Itoa(request.Offset))
-	urlParams.Add("limit", strconv.Itoa(request.Limit))
+	if err := r.includesValidate("issues", request.Includes); err != nil {
+		return i, 0, err
+	}
 
-	// Preparing includes
-	urlIncludes(&urlParams, request.Includes)
+	if err := r.issueFiltersValidate(request.Filters); err != nil {
+		return i, 0, err
+	}
 
-	// Preparing filters
-	issueURLFilters(&urlParams, request.Filters)
+	s, err := r.Get(&i, issueMultiGetURL(request), http.StatusOK)
 
-	ur := url.URL{
-		Path:     "/issues.json",
-		RawQuery: urlParams.Encode(),
+	// Redmine returns empty list if offset is beyond total count
+	if err == nil && i.Issues == nil {
+		i.Issues = []IssueObject{}
 	}
 
-	s, err := r.Get(&i, ur, http.StatusOK)
-
 	return i, s, err
 }
 
+// IssuesCount gets count of issues satisfying specified filters.
+// Request is made with `limit=1`, because Redmine replaces `limit=0` with default limit value
+//
+// see: http://www.redmine.org/projects/redmine/wiki/Rest_Issues#Listing-issues
+func (r *Context) IssuesCount(filters IssueGetRequestFilters) (int, int, error) {
+
+	var i issueCountResult
+
+	if err := r.issueFiltersValidate(filters); err != nil {
+		return 0, 0, err
+	}
+
+	s, err := r.Get(&i, issueMultiGetURL(IssueMultiGetRequest{
+		Filters: filters,
+		Limit:   1,
+	}), http.StatusOK)
+
+	return i.TotalCount, s, err
+}
+
+// IssueSingleGetWithUsers gets single issue info with users referenced within it (author, assignee,
+// watchers and journals users if appropriate includes are specified) resolved to full user objects
+// via `UsersResolve()`. Not visible users are absent in result (see `IssueUsersObject.User()`).
+// Error is returned if issue references more users than `resolve.Limit`
+func (r *Context) IssueSingleGetWithUsers(id int, request IssueSingleGetRequest, resolve UsersResolveRequest) (IssueUsersObject, int, error) {
+
+	i, status, err := r.IssueSingleGet(id, request)
+	if err != nil {
+		return IssueUsersObject{}, status, err
+	}
+
+	ids := []int{i.Author.ID, i.AssignedTo.ID}
+
+	for _, j := range i.Journals {
+		ids = append(ids, j.User.ID)
+	}
+
+	for _, w := range i.Watchers {
+		ids = append(ids, w.ID)
+	}
+
+	users, s, err := r.UsersResolve(ids, resolve)
+	if err != nil {
+		return IssueUsersObject{}, s, err
+	}
+
+	return IssueUsersObject{
+		Issue: i,
+		Users: users,
+	}, status, nil
+}
+
+// User returns resolved user for specified reference or nil if it has not been resolved
+func (o IssueUsersObject) User(ref IDName) *UserObject {
+
+	u, b := o.Users[ref.ID]
+	if b == false {
+		return nil
+	}
+
+	return &u
+}
+
+// IssueFiltersMyProjects returns specified filters scoped to projects the current user is a member of
+// (see `IssueGetRequestFilters.ProjectIDs`). Projects are got from current user memberships,
+// so it is requested once for the returned filters. Result is stored in metadata cache if it enabled
+// (separately for every API key and impersonated user)
+func (r *Context) IssueFiltersMyProjects(filters IssueGetRequestFilters) (IssueGetRequestFilters, int, error) {
+
+	v, status, err := r.cached("current_user_projects:"+r.cacheUserKey(), func() (interface{}, int, error) {
+
+		u, s, err := r.UserCurrentGet(UserCurrentGetRequest{
+			Includes: []string{"memberships"},
+		})
+
+		ids := []int{}
+		for _, m := range u.Memberships {
+			ids = append(ids, m.Project.ID)
+		}
+
+		return ids, s, err
+	})
+	if err != nil {
+		return filters, status, err
+	}
+
+	filters.ProjectIDs = append([]int{}, v.([]int)...)
+
+	return filters, status, nil
+}
+
 // IssueSingleGet gets single issue info
 //
 // see: http://www.redmine.org/projects/redmine/wiki/Rest_Issues#Showing-an-issue
@@ -289,10 +552,15 @@ func (r *Context) IssuesMultiGet(request IssueMultiGetRequest) (IssueResult, int
 // * changesets
 // * journals
 // * watchers - Since 2.3.0
+// * allowed_statuses - Since 5.0.0
 func (r *Context) IssueSingleGet(id int, request IssueSingleGetRequest) (IssueObject, int, error) {
 
 	var i issueSingleResult
 
+	if err := r.includesValidate("issue", request.Includes); err != nil {
+		return i.Issue, 0, err
+	}
+
 	urlParams := url.Values{}
 
 	// Preparing includes
@@ -308,36 +576,235 @@ func (r *Context) IssueSingleGet(id int, request IssueSingleGetRequest) (IssueOb
 	return i.Issue, status, err
 }
 
-// IssueCreate creates new issue
+// IssueCreate creates new issue. Returned issue contains custom fields values stored by server,
+// use `CustomFieldsCompare()` to check them against requested ones.
+// If both start and due dates are specified they are checked to be in order before sending request.
+// If issue tracker check is enabled (see `SetIssueTrackerCheck()`) specified tracker
+// is checked to be enabled for issue project
 //
 // see: http://www.redmine.org/projects/redmine/wiki/Rest_Issues#Creating-an-issue
 func (r *Context) IssueCreate(issue IssueCreateObject) (IssueObject, int, error) {
 
 	var i issueSingleResult
 
+	if err := issueDatesValidate(issue.StartDate, issue.DueDate); err != nil {
+		return i.Issue, 0, err
+	}
+
+	if r.issueTrackerCheck == true && issue.TrackerID != 0 {
+		b, status, err := r.ProjectHasTracker(strconv.Itoa(issue.ProjectID), issue.TrackerID)
+		if err != nil {
+			return i.Issue, status, err
+		}
+		if b == false {
+			return i.Issue, 0, fmt.Errorf("issue create error: tracker %d is not enabled for project %d", issue.TrackerID, issue.ProjectID)
+		}
+	}
+
 	ur := url.URL{
 		Path: "/issues.json",
 	}
 
-	status, err := r.Post(issueCreate{Issue: issue}, &i, ur, http.StatusCreated)
+	status, err := r.Post(issueCreate{Issue: issue}, &i, ur, http.StatusCreated, http.StatusOK)
 
 	return i.Issue, status, err
 }
 
-// IssueUpdate updates issue with specified ID
+// IssueImportAs creates new issue on behalf of user with specified login via user impersonation
+// (requires admin API key, see `SetSwitchUser()`). User is looked up at first (Redmine returns `412`
+// for unknown or locked users) and created issue author is checked to be the impersonated user.
+//
+// Note: Redmine API does not allow to set issue creation date, so `created_on` of imported issue is
+// the import time. Use custom field to keep original date if needed
+func (r *Context) IssueImportAs(login string, issue IssueCreateObject) (IssueObject, int, error) {
+
+	c := r.With(WithSwitchUser(login))
+
+	u, status, err := c.UserCurrentGet(UserCurrentGetRequest{})
+	if err != nil {
+		return IssueObject{}, status, err
+	}
+
+	i, status, err := c.IssueCreate(issue)
+	if err != nil || c.dryRunEnabled() == true {
+		return i, status, err
+	}
+
+	if i.ID != 0 && i.Author.ID != u.ID {
+		return i, status, fmt.Errorf("issue import error: issue %d author is %d instead of impersonated user %d", i.ID, i.Author.ID, u.ID)
+	}
+
+	return i, status, nil
+}
+
+// IssueCreateWithWatchers creates new issue with specified watchers.
+// Watchers are passed within create request at first. Watchers have not been accepted
+// by server on create (e.g. ignored `watcher_user_ids`) are added separately.
+// Returned map contains errors for watchers those could not be added
+func (r *Context) IssueCreateWithWatchers(issue IssueCreateObject, watcherIDs []int) (IssueObject, map[int]error, int, error) {
+
+	// Caller's slice must not be changed
+	ids := make([]int, 0, len(issue.WatcherUserIDs)+len(watcherIDs))
+	issue.WatcherUserIDs = append(append(ids, issue.WatcherUserIDs...), watcherIDs...)
+
+	i, status, err := r.IssueCreate(issue)
+	if err != nil {
+		return i, nil, status, err
+	}
+
+	// Created issue is unknown, watchers are passed within captured create request
+	if r.dryRunEnabled() == true {
+		return i, nil, status, nil
+	}
+
+	accepted := make(map[int]bool)
+
+	o, _, err := r.readPrimary().IssueSingleGet(i.ID, IssueSingleGetRequest{
+		Includes: []string{"watchers"},
+	})
+	if err == nil {
+		for _, w := range o.Watchers {
+			accepted[w.ID] = true
+		}
+	}
+
+	errs := make(map[int]error)
+
+	for _, id := range issue.WatcherUserIDs {
+
+		if accepted[id] == true {
+			continue
+		}
+
+		if _, err := r.IssueWatcherAdd(i.ID, id); err != nil {
+			errs[id] = err
+			continue
+		}
+
+		accepted[id] = true
+	}
+
+	if len(errs) == 0 {
+		errs = nil
+	}
+
+	return i, errs, status, nil
+}
+
+// IssueUpdate updates issue with specified ID.
+// If both start and due dates are specified they are checked to be in order before sending request
 //
 // see: http://www.redmine.org/projects/redmine/wiki/Rest_Projects#Updating-a-project
 func (r *Context) IssueUpdate(id int, issue IssueUpdateObject) (int, error) {
 
+	if err := issueDatesValidate(issue.StartDate, issue.DueDate); err != nil {
+		return 0, err
+	}
+
 	ur := url.URL{
 		Path: "/issues/" + strconv.Itoa(id) + ".json",
 	}
 
-	status, err := r.Put(issueUpdate{Issue: issue}, nil, ur, http.StatusNoContent)
+	status, err := r.Put(issueUpdate{Issue: issue}, nil, ur, http.StatusNoContent, http.StatusOK)
 
 	return status, err
 }
 
+// IssueAppendDescription appends text to description of issue with specified ID. If `Marker` is set and
+// current description already contains it, issue is not updated (e.g. for retried runs).
+//
+// Redmine has no conditional update for issues, so description changed by someone else between read and
+// update is overwritten. To recover, issue journals are inspected after update: if description replaced by
+// the update differs from the read one, text is appended to the replaced description and update is repeated.
+// Overwritten changes can not be detected if journals are not visible for current user
+func (r *Context) IssueAppendDescription(id int, request IssueAppendDescriptionRequest) (int, error) {
+
+	sep := request.Separator
+	if sep == "" {
+		sep = "\n\n"
+	}
+
+	// Description is read and checked right after updates
+	p := r.readPrimary()
+
+	i, status, err := p.IssueSingleGet(id, IssueSingleGetRequest{})
+	if err != nil {
+		return status, err
+	}
+
+	// Description replaced by the update is expected to be the read one or written by previous attempt
+	base := i.Description
+	written := ""
+
+	for a := 0; a < issueAppendDescriptionAttempts; a++ {
+
+		if request.Marker != "" && strings.Contains(base, request.Marker) {
+			return status, nil
+		}
+
+		d := request.Text
+		if base != "" {
+			d = base + sep + request.Text
+		}
+
+		status, err = r.IssueUpdate(id, IssueUpdateObject{
+			Description: d,
+		})
+		if err != nil || r.dryRunEnabled() == true {
+			return status, err
+		}
+
+		c, s, err := p.IssueSingleGet(id, IssueSingleGetRequest{
+			Includes: []string{"journals"},
+		})
+		if err != nil {
+			return s, err
+		}
+
+		replaced, b := issueDescriptionReplaced(c.Journals, d)
+		if b == false || replaced == base || (written != "" && replaced == written) {
+			return status, nil
+		}
+
+		// Concurrent change has been overwritten by the update
+		base = replaced
+		written = d
+	}
+
+	return status, fmt.Errorf("issue append description error: issue %d has been concurrently changed %d times", id, issueAppendDescriptionAttempts)
+}
+
+// IssueSetPrivate sets privacy flag for issue with specified ID.
+// Redmine silently ignores the flag if user has no `set_issues_private` or `set_own_issues_private`
+// permission, so issue is re-requested after update and an error matching `ErrNotApplied` (via `errors.Is()`)
+// is returned if flag has not been changed
+func (r *Context) IssueSetPrivate(id int, private bool) (int, error) {
+
+	ur := url.URL{
+		Path: "/issues/" + strconv.Itoa(id) + ".json",
+	}
+
+	status, err := r.Put(issueSetPrivate{
+		Issue: issueSetPrivateObject{
+			IsPrivate: private,
+		},
+	}, nil, ur, http.StatusNoContent, http.StatusOK)
+	if err != nil || r.dryRunEnabled() == true {
+		return status, err
+	}
+
+	i, s, err := r.readPrimary().IssueSingleGet(id, IssueSingleGetRequest{})
+	if err != nil {
+		return s, err
+	}
+
+	if (i.IsPrivate != 0) != private {
+		return status, fmt.Errorf("issue set private error: privacy flag of issue %d has not been changed (`set_issues_private` or `set_own_issues_private` permission required): %w", id, ErrNotApplied)
+	}
+
+	return status, nil
+}
+
 // IssueDelete deletes issue with specified ID
 //
 // see: http://www.redmine.org/projects/redmine/wiki/Rest_Issues#Deleting-an-issue
@@ -347,11 +814,102 @@ func (r *Context) IssueDelete(id int) (int, error) {
 		Path: "/issues/" + strconv.Itoa(id) + ".json",
 	}
 
-	status, err := r.Del(nil, nil, ur, http.StatusNoContent)
+	status, err := r.Del(nil, nil, ur, http.StatusNoContent, http.StatusOK)
 
 	return status, err
 }
 
+// IssuesBulkDelete deletes issues with specified IDs simultaneously. Duplicated IDs are ignored.
+// Deletion of more than `MaxCount` issues is refused without explicit override (see `IssuesBulkDeleteRequest`).
+// Errors for every issue are collected within result, issues already deleted (e.g. with its parents) are considered
+// as deleted. Returned error is not nil only if deletion has not been started
+func (r *Context) IssuesBulkDelete(ids []int, request IssuesBulkDeleteRequest) (IssuesBulkDeleteResult, int, error) {
+
+	type deleteResult struct {
+		status int
+		err    error
+	}
+
+	res := IssuesBulkDeleteResult{
+		Errors: make(map[int]error),
+	}
+
+	var uniq []int
+
+	specified := make(map[int]bool)
+	for _, id := range ids {
+		if specified[id] == false {
+			specified[id] = true
+			uniq = append(uniq, id)
+		}
+	}
+
+	max := request.MaxCount
+	if max <= 0 {
+		max = issuesBulkDeleteMaxDefault
+	}
+
+	if len(uniq) > max && request.Force == false {
+		return res, 0, fmt.Errorf("issues bulk delete error: %d issues requested to delete, max count is %d (override required)", len(uniq), max)
+	}
+
+	if request.DescendantsCheck != nil {
+
+		children := make([][]IssueChildrenObject, len(uniq))
+		results := make([]deleteResult, len(uniq))
+
+		r.parallelAdaptive(len(uniq), request.Concurrency, func(c *Context, n int) {
+			i, s, err := c.IssueSingleGet(uniq[n], IssueSingleGetRequest{
+				Includes: []string{"children"},
+			})
+			children[n] = i.Children
+			results[n] = deleteResult{status: s, err: err}
+		})
+
+		for n, e := range results {
+			if e.err != nil && IsNotFound(e.err) == false {
+				return res, e.status, e.err
+			}
+
+			for _, id := range issueChildrenIDs(children[n]) {
+				if specified[id] == false {
+					specified[id] = true
+					res.Descendants = append(res.Descendants, id)
+				}
+			}
+		}
+
+		if request.DescendantsCheck(uniq, res.Descendants) == false {
+			return res, 0, fmt.Errorf("issues bulk delete error: deletion has been cancelled by descendants check")
+		}
+	}
+
+	results := make([]deleteResult, len(uniq))
+
+	r.parallelAdaptive(len(uniq), request.Concurrency, func(c *Context, n int) {
+		s, err := c.IssueDelete(uniq[n])
+		results[n] = deleteResult{status: s, err: err}
+	})
+
+	status := 0
+
+	for n, e := range results {
+
+		if e.err != nil && IsNotFound(e.err) == false {
+			res.Errors[uniq[n]] = e.err
+			continue
+		}
+
+		if e.err == nil {
+			status = e.status
+		}
+
+		res.Deleted = append(res.Deleted, uniq[n])
+	}
+
+	return res, status, nil
+}
+
 // IssueWatcherAdd adds watcher into issue with specified ID
 //
 // see: http://www.redmine.org/projects/redmine/wiki/Rest_Issues#Adding-a-watcher
@@ -363,11 +921,41 @@ func (r *Context) IssueWatcherAdd(id int, userID int) (int, error) {
 
 	status, err := r.Post(issueWatcherAdd{
 		UserID: userID,
-	}, nil, ur, http.StatusNoContent)
+	}, nil, ur, http.StatusNoContent, http.StatusOK)
 
 	return status, err
 }
 
+// IssueIsWatching checks whether current user watches issue with specified ID.
+// If current user has no permission to view issue watchers `IssueWatchingStateUnknown` is returned
+func (r *Context) IssueIsWatching(id int) (IssueWatchingState, int, error) {
+
+	u, status, err := r.UserCurrentGet(UserCurrentGetRequest{})
+	if err != nil {
+		return IssueWatchingStateUnknown, status, err
+	}
+
+	i, status, err := r.IssueSingleGet(id, IssueSingleGetRequest{
+		Includes: []string{"watchers"},
+	})
+	if err != nil {
+		return IssueWatchingStateUnknown, status, err
+	}
+
+	// Redmine omits watchers in response if user has no permission to view them
+	if i.Watchers == nil {
+		return IssueWatchingStateUnknown, status, nil
+	}
+
+	for _, w := range i.Watchers {
+		if w.ID == u.ID {
+			return IssueWatchingStateWatching, status, nil
+		}
+	}
+
+	return IssueWatchingStateNotWatching, status, nil
+}
+
 // IssueWatcherDelete deletes watcher from issue with specified ID
 //
 // see: http://www.redmine.org/projects/redmine/wiki/Rest_Issues#Removing-a-watcher
@@ -377,13 +965,482 @@ func (r *Context) IssueWatcherDelete(id int, userID int) (int, error) {
 		Path: "/issues/" + strconv.Itoa(id) + "/watchers/" + strconv.Itoa(userID) + ".json",
 	}
 
-	status, err := r.Del(nil, nil, ur, http.StatusNoContent)
+	status, err := r.Del(nil, nil, ur, http.StatusNoContent, http.StatusOK)
 
 	return status, err
 }
 
+// IssuesByIDs gets info for issues with specified IDs regardless of its statuses.
+// Duplicated IDs are ignored, issues are requested in batches and returned in order of specified IDs.
+// Issues which are not visible for current user are skipped, so result may contain less issues than requested
+//
+// Available includes:
+// * attachments - Since 3.4.0
+// * relations
+// * journals
+// * children
+func (r *Context) IssuesByIDs(ids []int, includes []string) ([]IssueObject, int, error) {
+
+	var (
+		issues []IssueObject
+		uniq   []int
+		status int
+	)
+
+	seen := make(map[int]bool)
+	for _, id := range ids {
+		if seen[id] == false {
+			seen[id] = true
+			uniq = append(uniq, id)
+		}
+	}
+
+	found := make(map[int]IssueObject)
+
+	for n := 0; n < len(uniq); n += limitDefault {
+
+		var batch []string
+
+		for m := n; m < len(uniq) && m < n+limitDefault; m++ {
+			batch = append(batch, strconv.Itoa(uniq[m]))
+		}
+
+		i, s, err := r.IssuesAllGet(IssueAllGetRequest{
+			Includes: includes,
+			Filters: IssueGetRequestFilters{
+				Fields: map[string][]string{
+					"issue_id":  batch,
+					"status_id": {IssueFilterAny},
+				},
+			},
+		})
+		if err != nil {
+			return issues, s, err
+		}
+
+		status = s
+
+		for _, e := range i.Issues {
+			found[e.ID] = e
+		}
+	}
+
+	for _, id := range uniq {
+		if e, b := found[id]; b == true {
+			issues = append(issues, e)
+		}
+	}
+
+	return issues, status, nil
+}
+
+// IssueDoneRatioRollup computes done ratio for issue with specified ID by its subtasks tree:
+// done ratio of leaf subtasks weighted by its estimated hours (leaves without estimate are weighted
+// by average estimate, closed leaves are considered as done). If estimates are absent simple average is used.
+// If issue has no subtasks its own done ratio is returned.
+//
+// Note: result may differ from the one computed by Redmine for multi-level trees. Redmine weights direct
+// children of every parent (using their rolled up values and total estimates), while all leaves of the tree
+// are weighted here regardless of their depth
+func (r *Context) IssueDoneRatioRollup(id int) (IssueDoneRatioResult, int, error) {
+
+	var res IssueDoneRatioResult
+
+	i, status, err := r.IssueSingleGet(id, IssueSingleGetRequest{
+		Includes: []string{"children"},
+	})
+	if err != nil {
+		return res, status, err
+	}
+
+	leavesIDs := issueChildrenLeaves(i.Children)
+	if len(leavesIDs) == 0 {
+		res.DoneRatio = float64(i.DoneRatio)
+		return res, status, nil
+	}
+
+	leaves, status, err := r.IssuesByIDs(leavesIDs, nil)
+	if err != nil {
+		return res, status, err
+	}
+
+	statuses, status, err := r.IssueStatusAllGet()
+	if err != nil {
+		return res, status, err
+	}
+
+	closed := make(map[int]bool)
+	for _, s := range statuses {
+		closed[s.ID] = s.IsClosed
+	}
+
+	res.DoneRatio, res.SampleSize = issuesDoneRatio(leaves, closed)
+
+	return res, status, nil
+}
+
+// IssueLatestNoteGet gets the latest journal with non-empty notes for issue with specified ID.
+// Returns not found error (see `IsNotFound()`) if issue has no comments visible for current user
+func (r *Context) IssueLatestNoteGet(id int) (IssueJournalObject, int, error) {
+
+	var latest *IssueJournalObject
+
+	i, status, err := r.IssueSingleGet(id, IssueSingleGetRequest{
+		Includes: []string{"journals"},
+	})
+	if err != nil {
+		return IssueJournalObject{}, status, err
+	}
+
+	for n, j := range i.Journals {
+
+		if strings.TrimSpace(j.Notes) == "" {
+			continue
+		}
+
+		if latest == nil || j.CreatedOn >= latest.CreatedOn {
+			latest = &i.Journals[n]
+		}
+	}
+
+	if latest == nil {
+		return IssueJournalObject{}, status, &Error{
+			StatusCode: http.StatusNotFound,
+			Errors:     []string{fmt.Sprintf("issue latest note get error: issue %d has no notes", id)},
+		}
+	}
+
+	return *latest, status, nil
+}
+
+// IssueEstimatedHoursGet gets own and total (including subtasks) estimated hours for issue with specified ID.
+// Total value is got from `total_estimated_hours` provided by Redmine 3.3.0 and later. Redmine omits it if
+// nothing is estimated, so only if server version is older or not set (see `SetServerVersion()`) total value
+// is computed by issue subtasks (requested in batches). In this case total value is the sum of leaf subtasks estimates,
+// own estimates of issue and intermediate subtasks are not added, because older Redmine versions compute parent
+// issue estimate as the sum of its subtasks ones
+func (r *Context) IssueEstimatedHoursGet(id int) (IssueEstimatedHoursResult, int, error) {
+
+	var res IssueEstimatedHoursResult
+
+	i, status, err := r.IssueSingleGet(id, IssueSingleGetRequest{
+		Includes: []string{"children"},
+	})
+	if err != nil {
+		return res, status, err
+	}
+
+	res.Own = i.EstimatedHours
+
+	if i.TotalEstimatedHours != nil {
+		res.Total = *i.TotalEstimatedHours
+		return res, status, nil
+	}
+
+	res.Total = i.EstimatedHours
+
+	if r.serverVersion != "" && versionCompare(r.serverVersion, issueTotalEstimatedHoursSince) >= 0 {
+		return res, status, nil
+	}
+
+	ids := issueChildrenLeaves(i.Children)
+	if len(ids) == 0 {
+		return res, status, nil
+	}
+
+	leaves, status, err := r.IssuesByIDs(ids, nil)
+	if err != nil {
+		return res, status, err
+	}
+
+	res.Total = 0
+	for _, l := range leaves {
+		res.Total += l.EstimatedHours
+	}
+
+	return res, status, nil
+}
+
+// IssuesJournalAuthorGet gets issues where user with specified ID added notes within specified dates range,
+// along with matching journals. Candidate issues are selected by `updated_by` and `updated_on` (since range start)
+// filters, then its journals are inspected. If server version is older than 3.4.0 (see `SetServerVersion()`),
+// `updated_by` filter is not supported, so journals of all issues updated since range start are inspected
+func (r *Context) IssuesJournalAuthorGet(request IssueJournalAuthorGetRequest) ([]IssueJournalAuthorObject, int, error) {
+
+	type issueResult struct {
+		issue  IssueJournalAuthorObject
+		status int
+		err    error
+	}
+
+	var res []IssueJournalAuthorObject
+
+	fields := make(map[string][]string)
+	for k, v := range request.Filters.Fields {
+		fields[k] = v
+	}
+
+	if _, b := fields["status_id"]; b == false {
+		fields["status_id"] = []string{"*"}
+	}
+
+	// Issue may be updated after notes have been added, so candidates are not bounded by range end
+	if request.From.IsZero() == false {
+		fields["updated_on"] = []string{">=" + request.From.Format(DateFormat)}
+	}
+
+	filters := IssueGetRequestFilters{
+		Fields: fields,
+		Cf:     request.Filters.Cf,
+	}
+
+	if r.serverVersion == "" || versionCompare(r.serverVersion, issueFiltersUpdatedBySince) >= 0 {
+		filters.UpdatedByID = strconv.Itoa(request.UserID)
+	}
+
+	candidates, status, err := r.IssuesAllGet(IssueAllGetRequest{
+		Filters: filters,
+	})
+	if err != nil {
+		return res, status, err
+	}
+
+	results := make([]issueResult, len(candidates.Issues))
+
+	parallel(len(candidates.Issues), request.Concurrency, func(n int) {
+
+		i, s, err := r.IssueSingleGet(candidates.Issues[n].ID, IssueSingleGetRequest{
+			Includes: []string{"journals"},
+		})
+		if err != nil {
+			results[n] = issueResult{status: s, err: err}
+			return
+		}
+
+		o := IssueJournalAuthorObject{
+			Issue: i,
+		}
+
+		for _, j := range i.Journals {
+			if j.User.ID == request.UserID && j.Notes != "" && journalInRange(j, request.From, request.To) {
+				o.Journals = append(o.Journals, j)
+			}
+		}
+
+		results[n] = issueResult{issue: o, status: s}
+	})
+
+	for _, e := range results {
+
+		if e.err != nil {
+			return res, e.status, e.err
+		}
+
+		if len(e.issue.Journals) > 0 {
+			res = append(res, e.issue)
+		}
+	}
+
+	return res, status, nil
+}
+
+// IssueTimeEntryActivitiesGet gets time entry activities available for time logging
+// on issue with specified ID (i.e. activities enabled in the issue's project).
+// Project activities are stored in metadata cache if it enabled
+func (r *Context) IssueTimeEntryActivitiesGet(id int) ([]IDName, int, error) {
+
+	i, status, err := r.IssueSingleGet(id, IssueSingleGetRequest{})
+	if err != nil {
+		return nil, status, err
+	}
+
+	return r.ProjectTimeEntryActivitiesGet(strconv.Itoa(i.Project.ID))
+}
+
+// issueFiltersValidate checks specified filters are consistent and supported by server version (if it is set)
+func (r *Context) issueFiltersValidate(filters IssueGetRequestFilters) error {
+
+	if _, b := filters.Fields["project_id"]; b == true && filters.ProjectIDs != nil {
+		return fmt.Errorf("issue filters validate error: `project_id` field filter can not be used along with projects list")
+	}
+
+	if r.serverVersion == "" || versionCompare(r.serverVersion, issueFiltersUpdatedBySince) >= 0 {
+		return nil
+	}
+
+	for _, f := range []struct {
+		name  string
+		value string
+	}{
+		{"updated_by", filters.UpdatedByID},
+		{"last_updated_by", filters.LastUpdatedByID},
+	} {
+		if f.value != "" {
+			return fmt.Errorf("issue filters validate error: filter `%s` requires Redmine %s or later (server version: %s)", f.name, issueFiltersUpdatedBySince, r.serverVersion)
+		}
+	}
+
+	return nil
+}
+
+// issueDatesValidate checks that start date is not after due date. Unset dates are not checked.
+// Server is still authoritative for other cases
+func issueDatesValidate(startDate, dueDate *Date) error {
+
+	if startDate == nil || dueDate == nil || startDate.IsZero() == true || dueDate.IsZero() == true {
+		return nil
+	}
+
+	if startDate.Time().After(dueDate.Time()) {
+		return fmt.Errorf("issue dates validate error: `due_date` (%s) is earlier than `start_date` (%s)", dueDate, startDate)
+	}
+
+	return nil
+}
+
+// issueDescriptionReplaced returns description replaced by the latest update of issue description to specified value.
+// False is returned if there is no such update within specified journals
+func issueDescriptionReplaced(journals []IssueJournalObject, description string) (string, bool) {
+
+	for n := len(journals) - 1; n >= 0; n-- {
+		for _, d := range journals[n].Details {
+			if d.Property == "attr" && d.Name == "description" && d.NewValue == description {
+				return d.OldValue, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// issueChildrenLeaves returns IDs of the leaves of specified children tree
+func issueChildrenLeaves(children []IssueChildrenObject) []int {
+
+	var ids []int
+
+	for _, c := range children {
+		if len(c.Children) == 0 {
+			ids = append(ids, c.ID)
+		} else {
+			ids = append(ids, issueChildrenLeaves(c.Children)...)
+		}
+	}
+
+	return ids
+}
+
+// journalInRange checks journal has been created within specified dates range. Both dates are included,
+// journal creation time is compared in locations of range bounds
+func journalInRange(j IssueJournalObject, from, to time.Time) bool {
+
+	if from.IsZero() == true && to.IsZero() == true {
+		return true
+	}
+
+	t, err := time.Parse(time.RFC3339, j.CreatedOn)
+	if err != nil {
+		return false
+	}
+
+	if from.IsZero() == false && t.Before(dateStart(from)) {
+		return false
+	}
+
+	if to.IsZero() == false && t.Before(dateStart(to).AddDate(0, 0, 1)) == false {
+		return false
+	}
+
+	return true
+}
+
+// dateStart returns beginning of the day of specified time
+func dateStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// issueChildrenIDs returns IDs of all issues within specified children tree
+func issueChildrenIDs(children []IssueChildrenObject) []int {
+
+	var ids []int
+
+	for _, c := range children {
+		ids = append(ids, c.ID)
+		ids = append(ids, issueChildrenIDs(c.Children)...)
+	}
+
+	return ids
+}
+
+// issuesDoneRatio computes estimate weighted done ratio for specified issues.
+// Returns done ratio and issues count used for computation
+func issuesDoneRatio(issues []IssueObject, closed map[int]bool) (float64, int) {
+
+	var (
+		estimated, done float64
+		estimatedCount  int
+	)
+
+	if len(issues) == 0 {
+		return 0, 0
+	}
+
+	for _, i := range issues {
+		if i.EstimatedHours > 0 {
+			estimated += i.EstimatedHours
+			estimatedCount++
+		}
+	}
+
+	average := 1.0
+	if estimatedCount > 0 {
+		average = estimated / float64(estimatedCount)
+	}
+
+	for _, i := range issues {
+
+		e := i.EstimatedHours
+		if e <= 0 {
+			e = average
+		}
+
+		ratio := float64(i.DoneRatio)
+		if closed[i.Status.ID] == true {
+			ratio = 100
+		}
+
+		done += e * ratio
+	}
+
+	return done / (average * float64(len(issues))), len(issues)
+}
+
+// issueMultiGetURL builds URL for issues listing. Query parameters are sorted by key,
+// so equal requests always produce equal URLs
+func issueMultiGetURL(request IssueMultiGetRequest) url.URL {
+
+	urlParams := url.Values{}
+	urlParams.Add("offset", strconv.Itoa(request.Offset))
+	urlParams.Add("limit", strconv.Itoa(request.Limit))
+
+	// Preparing includes
+	urlIncludes(&urlParams, request.Includes)
+
+	// Preparing filters
+	issueURLFilters(&urlParams, request.Filters)
+
+	return url.URL{
+		Path:     "/issues.json",
+		RawQuery: urlParams.Encode(),
+	}
+}
+
 func issueURLFilters(urlParams *url.Values, filters IssueGetRequestFilters) {
 
+	if filters.ProjectIDs != nil {
+		issueURLFiltersExtended(urlParams, filters)
+		return
+	}
+
 	// Filter fields (e.g. `issue_id`, `tracker_id`, etc)
 	for n, s := range filters.Fields {
 		urlParams.Add(n, strings.Join(s, ","))
@@ -393,4 +1450,99 @@ func issueURLFilters(urlParams *url.Values, filters IssueGetRequestFilters) {
 	for _, c := range filters.Cf {
 		urlParams.Add("cf_"+strconv.Itoa(c.ID), c.Value)
 	}
+
+	if len(filters.UpdatedByID) > 0 {
+		urlParams.Add("updated_by", filters.UpdatedByID)
+	}
+
+	if len(filters.LastUpdatedByID) > 0 {
+		urlParams.Add("last_updated_by", filters.LastUpdatedByID)
+	}
+}
+
+// issueURLFiltersExtended adds filters into URL params in extended syntax.
+// Fields are sorted by name to keep URLs deterministic
+func issueURLFiltersExtended(urlParams *url.Values, filters IssueGetRequestFilters) {
+
+	expressions := make(map[string]string)
+
+	for n, s := range filters.Fields {
+		expressions[n] = strings.Join(s, ",")
+	}
+
+	for _, c := range filters.Cf {
+		expressions["cf_"+strconv.Itoa(c.ID)] = c.Value
+	}
+
+	if len(filters.UpdatedByID) > 0 {
+		expressions["updated_by"] = filters.UpdatedByID
+	}
+
+	if len(filters.LastUpdatedByID) > 0 {
+		expressions["last_updated_by"] = filters.LastUpdatedByID
+	}
+
+	if _, ok := expressions["status_id"]; ok == false {
+		expressions["status_id"] = "o"
+	}
+
+	add := func(field, op string, values []string) {
+		urlParams.Add("f[]", field)
+		urlParams.Add("op["+field+"]", op)
+		for _, v := range values {
+			urlParams.Add("v["+field+"][]", v)
+		}
+	}
+
+	if filters.ProjectIDs != nil {
+
+		// Project with zero ID does not exist, so empty projects list matches no issues
+		ids := []string{"0"}
+		if len(filters.ProjectIDs) > 0 {
+			ids = nil
+			for _, id := range filters.ProjectIDs {
+				ids = append(ids, strconv.Itoa(id))
+			}
+		}
+		add("project_id", "=", ids)
+
+		// Projects list replaces `project_id` field filter (the combination is refused by issues get methods)
+		delete(expressions, "project_id")
+	}
+
+	var fields []string
+	for n := range expressions {
+		fields = append(fields, n)
+	}
+	sort.Strings(fields)
+
+	for _, n := range fields {
+		op, values := issueFilterExpression(n, expressions[n])
+		add(n, op, values)
+	}
+}
+
+// issueFilterExpression splits short filter expression into operator and values
+func issueFilterExpression(field, expression string) (string, []string) {
+
+	ops := []string{"!*", "!~", ">=", "<=", "><", "*", "!", "~"}
+	if field == "status_id" {
+		ops = append(ops, "o", "c")
+	}
+
+	for _, op := range ops {
+
+		if strings.HasPrefix(expression, op) == false {
+			continue
+		}
+
+		v := strings.TrimPrefix(expression, op)
+		if v == "" {
+			return op, nil
+		}
+
+		return op, strings.Split(v, "|")
+	}
+
+	return "=", strings.Split(expression, "|")
 }
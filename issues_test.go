@@ -1,9 +1,19 @@
 package redmine
 
 import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"reflect"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 var (
@@ -16,11 +26,11 @@ var (
 	testIssueNote        = "Test issue note"
 	testIssuePrivateNote = "Test issue private note"
 
-	testIssueStartDate = "2022-07-01"
-	testIssueDueDate   = "2022-07-02"
+	testIssueStartDate = NewDate(2022, time.July, 1)
+	testIssueDueDate   = NewDate(2022, time.July, 2)
 
-	testIssueStartDate2 = "2022-07-03"
-	testIssueDueDate2   = "2022-07-04"
+	testIssueStartDate2 = NewDate(2022, time.July, 3)
+	testIssueDueDate2   = NewDate(2022, time.July, 4)
 )
 
 func TestIssuesCRUD(t *testing.T) {
@@ -56,9 +66,11 @@ func TestIssuesCRUD(t *testing.T) {
 	testIssueSingleGet(t, r, iCreated.ID, uCreated.ID)
 
 	// Update
+	testIssueLatestNoteGet(t, r, iCreated.ID, "")
 	testIssueNoteAdd(t, r, iCreated.ID, testIssueNote, false)
 	testIssueNoteAdd(t, r, iCreated.ID, testIssuePrivateNote, true)
 	testIssueUpdate(t, r, iCreated.ID)
+	testIssueLatestNoteGet(t, r, iCreated.ID, testIssuePrivateNote)
 
 	// Get multi
 	testIssueMultiGet(t, r, iCreated.ID)
@@ -91,8 +103,8 @@ func testIssueCreate(t *testing.T, r Context, projectID, userID int, upload *Att
 		Subject:        testIssueSubject,
 		WatcherUserIDs: w,
 		Description:    testIssueDescription,
-		StartDate:      testIssueStartDate,
-		DueDate:        testIssueDueDate,
+		StartDate:      &testIssueStartDate,
+		DueDate:        &testIssueDueDate,
 		Uploads:        u,
 	})
 	if err != nil {
@@ -104,7 +116,7 @@ func testIssueCreate(t *testing.T, r Context, projectID, userID int, upload *Att
 		t.Fatal("Issue create error:", err, s)
 	}
 
-	if o.StartDate != testIssueStartDate || o.DueDate != testIssueDueDate {
+	if o.StartDate != testIssueStartDate.String() || o.DueDate != testIssueDueDate.String() {
 		t.Fatal("Issue create error: incorrect issue start or due date")
 	}
 
@@ -131,7 +143,7 @@ func testIssueUpdate(t *testing.T, r Context, id int) {
 		t.Fatal("Issue update error:", err, s)
 	}
 
-	if o.StartDate != testIssueStartDate2 || o.DueDate != testIssueDueDate2 {
+	if o.StartDate != testIssueStartDate2.String() || o.DueDate != testIssueDueDate2.String() {
 		t.Fatal("Issue update error: incorrect issue start or due date")
 	}
 
@@ -168,6 +180,28 @@ func testIssueNoteAdd(t *testing.T, r Context, id int, notes string, privateNote
 	t.Logf("Issue notes add: success")
 }
 
+func testIssueLatestNoteGet(t *testing.T, r Context, id int, notes string) {
+
+	j, s, err := r.IssueLatestNoteGet(id)
+	if notes == "" {
+		if IsNotFound(err) == false {
+			t.Fatal("Issue latest note get error: not found error expected, got:", err, s)
+		}
+		t.Logf("Issue latest note get (no notes): success")
+		return
+	}
+
+	if err != nil {
+		t.Fatal("Issue latest note get error:", err, s)
+	}
+
+	if j.Notes != notes {
+		t.Fatal("Issue latest note get error: incorrect note text")
+	}
+
+	t.Logf("Issue latest note get: success")
+}
+
 func testIssueDetele(t *testing.T, r Context, id int) {
 
 	_, err := r.IssueDelete(id)
@@ -273,3 +307,1206 @@ func testIssueWatcherDelete(t *testing.T, r Context, id int, userID int) {
 
 	t.Logf("Issue delete watcher: success")
 }
+
+func TestIssuesURLDeterministic(t *testing.T) {
+
+	request := IssueMultiGetRequest{
+		Includes: []string{"relations", "attachments"},
+		Filters: IssueGetRequestFilters{
+			Fields: map[string][]string{
+				"tracker_id":  {"1", "2"},
+				"status_id":   {"open"},
+				"project_id":  {"3"},
+				"assigned_to": {"me"},
+			},
+			Cf: []IssueGetRequestFiltersCf{
+				{ID: 5, Value: "foo"},
+				{ID: 2, Value: "bar"},
+			},
+		},
+		Limit:  25,
+		Offset: 50,
+	}
+
+	expected := "/issues.json?assigned_to=me&cf_2=bar&cf_5=foo&include=relations%2Cattachments&limit=25&offset=50&project_id=3&status_id=open&tracker_id=1%2C2"
+
+	for i := 0; i < 100; i++ {
+		u := issueMultiGetURL(request)
+		if u.String() != expected {
+			t.Fatalf("Issues URL error: unexpected URL (expected: %s, got: %s)", expected, u.String())
+		}
+	}
+
+	t.Logf("Issues URL deterministic: success")
+}
+
+func TestIssuesURLFiltersUpdatedBy(t *testing.T) {
+
+	for _, v := range []string{"5", IssueFilterMe} {
+
+		urlParams := url.Values{}
+
+		issueURLFilters(&urlParams, IssueGetRequestFilters{
+			UpdatedByID: v,
+		})
+
+		if urlParams.Encode() != "updated_by="+v {
+			t.Fatal("Issues URL filters error: incorrect `updated_by` filter:", urlParams.Encode())
+		}
+
+		urlParams = url.Values{}
+
+		issueURLFilters(&urlParams, IssueGetRequestFilters{
+			LastUpdatedByID: v,
+		})
+
+		if urlParams.Encode() != "last_updated_by="+v {
+			t.Fatal("Issues URL filters error: incorrect `last_updated_by` filter:", urlParams.Encode())
+		}
+	}
+
+	urlParams := url.Values{}
+
+	issueURLFilters(&urlParams, IssueGetRequestFilters{})
+
+	if len(urlParams) != 0 {
+		t.Fatal("Issues URL filters error: unexpected filters:", urlParams.Encode())
+	}
+
+	t.Logf("Issues URL filters `updated_by`: success")
+}
+
+func TestIssuesFiltersUpdatedByServerVersion(t *testing.T) {
+
+	var (
+		r        Context
+		requests int
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"issues":[],"total_count":0,"offset":0,"limit":100}`))
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+
+	if err := r.SetServerVersion("3.3.2"); err != nil {
+		t.Fatal("Set server version error:", err)
+	}
+
+	_, _, err := r.IssuesAllGet(IssueAllGetRequest{
+		Filters: IssueGetRequestFilters{
+			LastUpdatedByID: IssueFilterMe,
+		},
+	})
+	if err == nil || requests != 0 {
+		t.Fatal("Issues filters error: filter has been sent to older server:", err, requests)
+	}
+
+	if _, _, err := r.IssuesCount(IssueGetRequestFilters{UpdatedByID: "5"}); err == nil || requests != 0 {
+		t.Fatal("Issues filters error: filter has been sent to older server:", err, requests)
+	}
+
+	if err := r.SetServerVersion("3.4.0"); err != nil {
+		t.Fatal("Set server version error:", err)
+	}
+
+	if _, s, err := r.IssuesCount(IssueGetRequestFilters{LastUpdatedByID: "5"}); err != nil || requests != 1 {
+		t.Fatal("Issues filters error:", err, s)
+	}
+
+	t.Logf("Issues filters `updated_by` server version: success")
+}
+
+func TestIssuesURLFiltersCf(t *testing.T) {
+
+	for _, e := range []struct {
+		cf       IssueGetRequestFiltersCf
+		expected string
+	}{
+		{IssueGetRequestFiltersCf{ID: 3, Value: IssueFilterMe}, "cf_3=me"},
+		{IssueGetRequestFiltersCf{ID: 4, Value: "12|15"}, "cf_4=12%7C15"},
+		{IssueGetRequestFiltersCf{ID: 4, Value: IssueFilterNone}, "cf_4=%21%2A"},
+		{IssueGetRequestFiltersCf{ID: 4, Value: IssueFilterAny}, "cf_4=%2A"},
+	} {
+
+		urlParams := url.Values{}
+
+		issueURLFilters(&urlParams, IssueGetRequestFilters{
+			Cf: []IssueGetRequestFiltersCf{e.cf},
+		})
+
+		if urlParams.Encode() != e.expected {
+			t.Fatalf("Issues URL filters error: incorrect custom field filter (expected: %s, got: %s)", e.expected, urlParams.Encode())
+		}
+
+		v, _ := url.ParseQuery(urlParams.Encode())
+		if v.Get("cf_"+strconv.Itoa(e.cf.ID)) != e.cf.Value {
+			t.Fatal("Issues URL filters error: custom field filter value has been changed:", v.Encode())
+		}
+	}
+
+	t.Logf("Issues URL filters custom fields: success")
+}
+
+func TestIssuesURLFiltersProjectIDs(t *testing.T) {
+
+	urlParams := url.Values{}
+
+	issueURLFilters(&urlParams, IssueGetRequestFilters{
+		Fields: map[string][]string{
+			"tracker_id": {"1|2"},
+		},
+		Cf: []IssueGetRequestFiltersCf{
+			{ID: 3, Value: "!~draft"},
+		},
+		ProjectIDs: []int{5, 8},
+	})
+
+	for k, e := range map[string][]string{
+		"f[]":             {"project_id", "cf_3", "status_id", "tracker_id"},
+		"op[project_id]":  {"="},
+		"v[project_id][]": {"5", "8"},
+		"op[cf_3]":        {"!~"},
+		"v[cf_3][]":       {"draft"},
+		"op[status_id]":   {"o"},
+		"v[status_id][]":  nil,
+		"op[tracker_id]":  {"="},
+		"v[tracker_id][]": {"1", "2"},
+		"tracker_id":      nil,
+	} {
+		if strings.Join(urlParams[k], ",") != strings.Join(e, ",") {
+			t.Fatalf("Issues URL filters error: incorrect `%s` param (expected: %v, got: %v)", k, e, urlParams[k])
+		}
+	}
+
+	urlParams = url.Values{}
+
+	issueURLFilters(&urlParams, IssueGetRequestFilters{
+		ProjectIDs: []int{},
+	})
+
+	if urlParams.Get("v[project_id][]") != "0" {
+		t.Fatal("Issues URL filters error: empty projects list must match no issues:", urlParams.Encode())
+	}
+
+	// Projects list replaces `project_id` field filter
+	filters := IssueGetRequestFilters{
+		Fields: map[string][]string{
+			"project_id": {"3"},
+		},
+		ProjectIDs: []int{5},
+	}
+
+	urlParams = url.Values{}
+
+	issueURLFilters(&urlParams, filters)
+
+	if strings.Join(urlParams["f[]"], ",") != "project_id,status_id" || strings.Join(urlParams["v[project_id][]"], ",") != "5" {
+		t.Fatal("Issues URL filters error: incorrect `project_id` filter:", urlParams.Encode())
+	}
+
+	var r Context
+
+	if _, _, err := r.IssuesMultiGet(IssueMultiGetRequest{Filters: filters}); err == nil {
+		t.Fatal("Issues URL filters error: `project_id` field filter along with projects list has not been refused")
+	}
+
+	t.Logf("Issues URL filters project IDs: success")
+}
+
+func TestIssuesOffsetBeyondTotal(t *testing.T) {
+
+	var r Context
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		// Records have been deleted after the first page has been returned
+		if req.URL.Query().Get("offset") == "0" {
+			w.Write([]byte(`{"issues":[{"id":1},{"id":2}],"total_count":5,"offset":0,"limit":2}`))
+			return
+		}
+
+		w.Write([]byte(`{"issues":[],"total_count":2,"offset":` + req.URL.Query().Get("offset") + `,"limit":100}`))
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+
+	i, s, err := r.IssuesMultiGet(IssueMultiGetRequest{
+		Offset: 10,
+		Limit:  100,
+	})
+	if err != nil {
+		t.Fatal("Issues get error:", err, s)
+	}
+
+	if i.Issues == nil || len(i.Issues) != 0 {
+		t.Fatal("Issues get error: empty list expected for offset past the end, got:", i.Issues)
+	}
+
+	a, s, err := r.IssuesAllGet(IssueAllGetRequest{})
+	if err != nil {
+		t.Fatal("Issues all get error:", err, s)
+	}
+
+	if len(a.Issues) != 2 || a.TotalCount != 2 {
+		t.Fatalf("Issues all get error: incorrect result (issues: %d, total count: %d)", len(a.Issues), a.TotalCount)
+	}
+
+	t.Logf("Issues offset beyond total: success")
+}
+
+func TestIssuesBulkDelete(t *testing.T) {
+
+	var (
+		r       Context
+		mu      sync.Mutex
+		deleted []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		if req.Method == http.MethodDelete {
+
+			if req.URL.Path == "/issues/3.json" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+
+			mu.Lock()
+			deleted = append(deleted, req.URL.Path)
+			mu.Unlock()
+
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch req.URL.Path {
+		case "/issues/1.json":
+			w.Write([]byte(`{"issue":{"id":1,"children":[{"id":4,"children":[{"id":5}]},{"id":2}]}}`))
+		default:
+			w.Write([]byte(`{"issue":{"id":0}}`))
+		}
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+
+	_, _, err := r.IssuesBulkDelete([]int{1, 2, 2, 3}, IssuesBulkDeleteRequest{
+		MaxCount: 2,
+	})
+	if err == nil || len(deleted) != 0 {
+		t.Fatal("Issues bulk delete error: safety cap has not been applied")
+	}
+
+	_, _, err = r.IssuesBulkDelete([]int{1, 2, 3}, IssuesBulkDeleteRequest{
+		DescendantsCheck: func(ids []int, descendants []int) bool {
+			return false
+		},
+	})
+	if err == nil || len(deleted) != 0 {
+		t.Fatal("Issues bulk delete error: descendants check has not been applied")
+	}
+
+	res, s, err := r.IssuesBulkDelete([]int{1, 2, 2, 3}, IssuesBulkDeleteRequest{
+		MaxCount: 2,
+		Force:    true,
+		DescendantsCheck: func(ids []int, descendants []int) bool {
+			return len(ids) == 3 && len(descendants) == 2
+		},
+	})
+	if err != nil {
+		t.Fatal("Issues bulk delete error:", err, s)
+	}
+
+	if len(res.Deleted) != 2 || len(deleted) != 2 || len(res.Descendants) != 2 || IsForbidden(res.Errors[3]) == false {
+		t.Fatalf("Issues bulk delete error: incorrect result (deleted: %v, descendants: %v, errors: %v)", res.Deleted, res.Descendants, res.Errors)
+	}
+
+	t.Logf("Issues bulk delete: success")
+}
+
+func TestIssueImportAs(t *testing.T) {
+
+	var r Context
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		if req.Header.Get("X-Redmine-Switch-User") != "jsmith" {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch req.URL.Path {
+		case "/users/current.json":
+			w.Write([]byte(`{"user":{"id":5,"login":"jsmith"}}`))
+		case "/issues.json":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"issue":{"id":10,"author":{"id":5,"name":"John Smith"}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+
+	i, s, err := r.IssueImportAs("jsmith", IssueCreateObject{
+		ProjectID: 1,
+		Subject:   testIssueSubject,
+	})
+	if err != nil {
+		t.Fatal("Issue import error:", err, s)
+	}
+
+	if i.ID != 10 || i.Author.ID != 5 {
+		t.Fatal("Issue import error: incorrect issue:", i.ID, i.Author.ID)
+	}
+
+	if _, s, err := r.UserCurrentGet(UserCurrentGetRequest{}); s != http.StatusPreconditionFailed {
+		t.Fatal("Issue import error: impersonation has been applied to original context:", err, s)
+	}
+
+	t.Logf("Issue import as: success")
+}
+
+func TestIssuesAllGetConcurrent(t *testing.T) {
+
+	var (
+		r       Context
+		mu      sync.Mutex
+		offsets = make(map[string]int)
+	)
+
+	// Server returns at most 25 records per page
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		q := req.URL.Query()
+
+		mu.Lock()
+		offsets[q.Get("offset")]++
+		mu.Unlock()
+
+		offset, _ := strconv.Atoi(q.Get("offset"))
+
+		var issues []string
+		for id := offset + 1; id <= 200 && id <= offset+25; id++ {
+			issues = append(issues, `{"id":`+strconv.Itoa(id)+`}`)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"issues":[` + strings.Join(issues, ",") + `],"total_count":200,"offset":` + q.Get("offset") + `,"limit":25}`))
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+	r.SetPaginationConcurrency(8)
+
+	i, s, err := r.IssuesAllGet(IssueAllGetRequest{
+		Filters: IssueGetRequestFilters{
+			Fields: map[string][]string{
+				"tracker_id": {"1"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal("Issues all get error:", err, s)
+	}
+
+	if len(i.Issues) != 200 || i.TotalCount != 200 {
+		t.Fatalf("Issues all get error: incorrect result (issues: %d, total count: %d)", len(i.Issues), i.TotalCount)
+	}
+
+	for n, e := range i.Issues {
+		if e.ID != n+1 {
+			t.Fatalf("Issues all get error: incorrect issue at position %d: %d", n, e.ID)
+		}
+	}
+
+	if len(offsets) != 8 {
+		t.Fatal("Issues all get error: incorrect offsets requested:", offsets)
+	}
+
+	for o, c := range offsets {
+		if c != 1 {
+			t.Fatalf("Issues all get error: offset %s has been requested %d times", o, c)
+		}
+	}
+
+	t.Logf("Issues all get concurrent: success")
+}
+
+func TestIssueAppendDescriptionConcurrentChange(t *testing.T) {
+
+	var (
+		r           Context
+		mu          sync.Mutex
+		description = "A"
+		journals    []string
+		gets, puts  int
+	)
+
+	journal := func(old, new string) string {
+		return `{"id":` + strconv.Itoa(len(journals)+1) + `,"details":[{"property":"attr","name":"description","old_value":` + strconv.Quote(old) + `,"new_value":` + strconv.Quote(new) + `}]}`
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch req.Method {
+		case http.MethodGet:
+
+			gets++
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"issue":{"id":1,"description":` + strconv.Quote(description) + `,"journals":[` + strings.Join(journals, ",") + `]}}`))
+
+			// Issue is changed by someone else between the read and the write
+			if gets == 1 {
+				journals = append(journals, journal(description, "B"))
+				description = "B"
+			}
+		case http.MethodPut:
+
+			puts++
+
+			var u issueUpdate
+			if err := json.NewDecoder(req.Body).Decode(&u); err != nil {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				return
+			}
+
+			journals = append(journals, journal(description, u.Issue.Description))
+			description = u.Issue.Description
+
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+
+	s, err := r.IssueAppendDescription(1, IssueAppendDescriptionRequest{
+		Text: "C",
+	})
+	if err != nil {
+		t.Fatal("Issue append description error:", err, s)
+	}
+
+	if description != "B\n\nC" || puts != 2 {
+		t.Fatalf("Issue append description error: concurrent change has been lost (description: %q, updates: %d)", description, puts)
+	}
+
+	t.Logf("Issue append description with concurrent change: success")
+}
+
+func TestIssuesJournalAuthorGet(t *testing.T) {
+
+	var (
+		r         Context
+		mu        sync.Mutex
+		updatedOn []string
+		updatedBy []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch req.URL.Path {
+		case "/issues.json":
+			mu.Lock()
+			updatedOn = append(updatedOn, req.URL.Query().Get("updated_on"))
+			updatedBy = append(updatedBy, req.URL.Query().Get("updated_by"))
+			mu.Unlock()
+			// Issue has been updated by someone else after the range end
+			w.Write([]byte(`{"issues":[{"id":1,"updated_on":"2022-08-05T10:00:00Z"}],"total_count":1,"offset":0,"limit":100}`))
+		case "/issues/1.json":
+			w.Write([]byte(`{"issue":{"id":1,"updated_on":"2022-08-05T10:00:00Z","journals":[` +
+				`{"id":1,"user":{"id":5},"notes":"before range","created_on":"2022-06-30T23:59:59Z"},` +
+				`{"id":2,"user":{"id":5},"notes":"first day","created_on":"2022-07-01T00:00:00Z"},` +
+				`{"id":3,"user":{"id":5},"notes":"last day","created_on":"2022-07-31T15:00:00Z"},` +
+				`{"id":4,"user":{"id":6},"notes":"other user","created_on":"2022-07-15T10:00:00Z"},` +
+				`{"id":5,"user":{"id":5},"notes":"after range","created_on":"2022-08-01T00:00:00Z"},` +
+				`{"id":6,"user":{"id":6},"created_on":"2022-08-05T10:00:00Z"}]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+
+	// `updated_by` filter is not sent to servers not supporting it
+	for version, expected := range map[string]string{
+		"":      "5",
+		"3.4.0": "5",
+		"3.3.2": "",
+	} {
+
+		r.serverVersion = version
+		updatedOn = nil
+		updatedBy = nil
+
+		issues, s, err := r.IssuesJournalAuthorGet(IssueJournalAuthorGetRequest{
+			UserID: 5,
+			From:   time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC),
+			To:     time.Date(2022, 7, 31, 0, 0, 0, 0, time.UTC),
+		})
+		if err != nil {
+			t.Fatalf("Issues journal author get error for server version `%s`: %v (%d)", version, err, s)
+		}
+
+		if len(updatedOn) != 1 || updatedOn[0] != ">=2022-07-01" {
+			t.Fatal("Issues journal author get error: incorrect `updated_on` filter:", updatedOn)
+		}
+
+		if len(updatedBy) != 1 || updatedBy[0] != expected {
+			t.Fatalf("Issues journal author get error: incorrect `updated_by` filter for server version `%s`: %v", version, updatedBy)
+		}
+
+		if len(issues) != 1 || len(issues[0].Journals) != 2 || issues[0].Journals[0].ID != 2 || issues[0].Journals[1].ID != 3 {
+			t.Fatalf("Issues journal author get error: incorrect result: %+v", issues)
+		}
+	}
+
+	t.Logf("Issues journal author get: success")
+}
+
+func TestIssueCreateWithWatchers(t *testing.T) {
+
+	var (
+		r       Context
+		mu      sync.Mutex
+		created []int
+		added   []int
+	)
+
+	// Server ignores `watcher_user_ids` on create, watcher 7 can not be added
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case req.Method == http.MethodPost && req.URL.Path == "/issues.json":
+			var c issueCreate
+			if err := json.NewDecoder(req.Body).Decode(&c); err != nil {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				return
+			}
+			created = c.Issue.WatcherUserIDs
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"issue":{"id":10}}`))
+		case req.Method == http.MethodGet && req.URL.Path == "/issues/10.json":
+			w.Write([]byte(`{"issue":{"id":10,"watchers":[{"id":3}]}}`))
+		case req.Method == http.MethodPost && req.URL.Path == "/issues/10/watchers.json":
+			var a issueWatcherAdd
+			if err := json.NewDecoder(req.Body).Decode(&a); err != nil || a.UserID == 7 {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				return
+			}
+			added = append(added, a.UserID)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+
+	watchers := make([]int, 1, 4)
+	watchers[0] = 3
+
+	i, errs, s, err := r.IssueCreateWithWatchers(IssueCreateObject{
+		ProjectID:      1,
+		Subject:        testIssueSubject,
+		WatcherUserIDs: watchers,
+	}, []int{5, 7})
+	if err != nil {
+		t.Fatal("Issue create with watchers error:", err, s)
+	}
+
+	if i.ID != 10 || len(created) != 3 {
+		t.Fatalf("Issue create with watchers error: incorrect create request (issue: %d, watchers: %v)", i.ID, created)
+	}
+
+	if len(added) != 1 || added[0] != 5 || len(errs) != 1 || errs[7] == nil {
+		t.Fatalf("Issue create with watchers error: incorrect fallback (added: %v, errors: %v)", added, errs)
+	}
+
+	if watchers[:2][1] != 0 {
+		t.Fatal("Issue create with watchers error: caller's watchers slice has been changed:", watchers[:3])
+	}
+
+	t.Logf("Issue create with watchers: success")
+}
+
+func TestIssueSetPrivateNotApplied(t *testing.T) {
+
+	var r Context
+
+	// Server ignores privacy flag
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		if req.Method == http.MethodPut {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"issue":{"id":1,"is_private":0}}`))
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+
+	s, err := r.IssueSetPrivate(1, true)
+	if errors.Is(err, ErrNotApplied) == false || IsForbidden(err) == true || s != http.StatusNoContent {
+		t.Fatal("Issue set private error: not applied error expected, got:", err, s)
+	}
+
+	if s, err := r.IssueSetPrivate(1, false); err != nil {
+		t.Fatal("Issue set private error:", err, s)
+	}
+
+	t.Logf("Issue set private not applied: success")
+}
+
+func TestIssueEstimatedHoursGet(t *testing.T) {
+
+	var (
+		r        Context
+		mu       sync.Mutex
+		requests []string
+		batches  []string
+		total    = `,"total_estimated_hours":7.5`
+	)
+
+	// Issues tree: 1 -> (2 -> (3, 4), 5). Older Redmine versions store sum of leaves estimates as parents ones
+	estimates := map[string]string{"2": "1.5", "3": "0.5", "4": "1", "5": "2"}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		requests = append(requests, req.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch req.URL.Path {
+		case "/issues/1.json":
+			w.Write([]byte(`{"issue":{"id":1,"estimated_hours":3.5` + total + `,"children":[{"id":2,"children":[{"id":3},{"id":4}]},{"id":5}]}}`))
+		case "/issues.json":
+			batch := req.URL.Query().Get("issue_id")
+			batches = append(batches, batch)
+			var issues []string
+			for _, id := range strings.Split(batch, ",") {
+				issues = append(issues, `{"id":`+id+`,"estimated_hours":`+estimates[id]+`}`)
+			}
+			w.Write([]byte(`{"issues":[` + strings.Join(issues, ",") + `],"total_count":` + strconv.Itoa(len(issues)) + `,"offset":0,"limit":100}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+
+	for _, e := range []struct {
+		version  string
+		total    string
+		expected float64
+		requests int
+	}{
+		{"", `,"total_estimated_hours":7.5`, 7.5, 1},
+		{"4.2.0", `,"total_estimated_hours":null`, 3.5, 1},
+		{"3.2.0", ``, 3.5, 2},
+		{"", ``, 3.5, 2},
+	} {
+
+		r.serverVersion = e.version
+
+		mu.Lock()
+		total = e.total
+		requests = nil
+		batches = nil
+		mu.Unlock()
+
+		res, s, err := r.IssueEstimatedHoursGet(1)
+		if err != nil {
+			t.Fatal("Issue estimated hours get error:", err, s)
+		}
+
+		if res.Own != 3.5 || res.Total != e.expected || len(requests) != e.requests {
+			t.Fatalf("Issue estimated hours get error: incorrect result for server version `%s` (own: %v, total: %v, requests: %v)", e.version, res.Own, res.Total, requests)
+		}
+
+		// Only leaf subtasks are requested
+		if e.requests > 1 && (len(batches) != 1 || batches[0] != "3,4,5") {
+			t.Fatal("Issue estimated hours get error: incorrect subtasks requested:", batches)
+		}
+	}
+
+	t.Logf("Issue estimated hours get: success")
+}
+
+func TestIssuesByIDs(t *testing.T) {
+
+	var (
+		r       Context
+		mu      sync.Mutex
+		batches [][]string
+		invalid []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		q := req.URL.Query()
+
+		if q.Get("status_id") != IssueFilterAny {
+			mu.Lock()
+			invalid = append(invalid, req.URL.RawQuery)
+			mu.Unlock()
+		}
+
+		batch := strings.Split(q.Get("issue_id"), ",")
+
+		mu.Lock()
+		batches = append(batches, batch)
+		mu.Unlock()
+
+		// Issues are returned in descending order of IDs, issue 7 is not visible
+		var ids []int
+		for _, e := range batch {
+			if id, _ := strconv.Atoi(e); id != 7 {
+				ids = append(ids, id)
+			}
+		}
+		sort.Sort(sort.Reverse(sort.IntSlice(ids)))
+
+		var issues []string
+		for _, id := range ids {
+			issues = append(issues, `{"id":`+strconv.Itoa(id)+`}`)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"issues":[` + strings.Join(issues, ",") + `],"total_count":` + strconv.Itoa(len(ids)) + `,"offset":0,"limit":100}`))
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+
+	var ids []int
+	for id := 1; id <= 250; id++ {
+		ids = append(ids, id)
+	}
+	ids = append(ids, 5, 250, 1)
+
+	issues, s, err := r.IssuesByIDs(ids, nil)
+	if err != nil {
+		t.Fatal("Issues by IDs error:", err, s)
+	}
+
+	if len(invalid) > 0 {
+		t.Fatal("Issues by IDs error: incorrect status filter:", invalid)
+	}
+
+	sort.Slice(batches, func(a, b int) bool {
+		return batches[a][0] < batches[b][0]
+	})
+
+	if len(batches) != 3 || len(batches[0]) != 100 || len(batches[1]) != 100 || len(batches[2]) != 50 {
+		t.Fatal("Issues by IDs error: incorrect batches:", len(batches))
+	}
+
+	if batches[0][0] != "1" || batches[0][99] != "100" || batches[1][0] != "101" || batches[2][49] != "250" {
+		t.Fatal("Issues by IDs error: incorrect batches bounds:", batches[0][0], batches[0][99], batches[1][0], batches[2][49])
+	}
+
+	if len(issues) != 249 {
+		t.Fatal("Issues by IDs error: incorrect issues count:", len(issues))
+	}
+
+	for n, i := range issues {
+		expected := n + 1
+		if n >= 6 {
+			expected++
+		}
+		if i.ID != expected {
+			t.Fatalf("Issues by IDs error: incorrect order: issue %d at position %d", i.ID, n)
+		}
+	}
+
+	t.Logf("Issues by IDs: success")
+}
+
+func TestIssuesCount(t *testing.T) {
+
+	var (
+		r       Context
+		queries []url.Values
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		queries = append(queries, req.URL.Query())
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"issues":[{"id":42}],"total_count":137,"offset":0,"limit":1}`))
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+
+	c, s, err := r.IssuesCount(IssueGetRequestFilters{
+		Fields: map[string][]string{
+			"tracker_id": {"2"},
+		},
+	})
+	if err != nil {
+		t.Fatal("Issues count error:", err, s)
+	}
+
+	if c != 137 {
+		t.Fatal("Issues count error: incorrect count:", c)
+	}
+
+	if len(queries) != 1 || queries[0].Get("limit") != "1" || queries[0].Get("tracker_id") != "2" {
+		t.Fatal("Issues count error: incorrect request:", queries)
+	}
+
+	t.Logf("Issues count: success")
+}
+
+func TestIssueIsWatching(t *testing.T) {
+
+	var (
+		r        Context
+		includes []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if strings.HasPrefix(req.URL.Path, "/issues/") == true {
+			includes = append(includes, req.URL.Query().Get("include"))
+		}
+
+		switch req.URL.Path {
+		case "/users/current.json":
+			w.Write([]byte(`{"user":{"id":5,"login":"jsmith"}}`))
+		case "/issues/1.json":
+			w.Write([]byte(`{"issue":{"id":1,"watchers":[{"id":6,"name":"John Doe"},{"id":5,"name":"John Smith"}]}}`))
+		case "/issues/2.json":
+			w.Write([]byte(`{"issue":{"id":2,"watchers":[]}}`))
+		case "/issues/3.json":
+			// Watchers are omitted if user has no permission to view them
+			w.Write([]byte(`{"issue":{"id":3}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+
+	for id, expected := range map[int]IssueWatchingState{
+		1: IssueWatchingStateWatching,
+		2: IssueWatchingStateNotWatching,
+		3: IssueWatchingStateUnknown,
+	} {
+		w, s, err := r.IssueIsWatching(id)
+		if err != nil {
+			t.Fatal("Issue is watching error:", err, s)
+		}
+		if w != expected {
+			t.Fatalf("Issue is watching error: incorrect state for issue %d: %s (expected: %s)", id, w, expected)
+		}
+	}
+
+	for _, i := range includes {
+		if i != "watchers" {
+			t.Fatal("Issue is watching error: incorrect includes:", includes)
+		}
+	}
+
+	if _, _, err := r.IssueIsWatching(4); IsNotFound(err) == false {
+		t.Fatal("Issue is watching error: not found error expected:", err)
+	}
+
+	t.Logf("Issue is watching: success")
+}
+
+func TestIssueCreateTrackerCheck(t *testing.T) {
+
+	var (
+		r        Context
+		projects int
+		creates  int
+		includes []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch req.Method + " " + req.URL.Path {
+		case "GET /projects/1.json":
+			projects++
+			includes = append(includes, req.URL.Query().Get("include"))
+			w.Write([]byte(`{"project":{"id":1,"trackers":[{"id":1,"name":"Bug"},{"id":3,"name":"Support"}]}}`))
+		case "POST /issues.json":
+			creates++
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"issue":{"id":10}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+	r.SetMetadataCache(time.Minute)
+
+	if b, s, err := r.ProjectHasTracker("1", 3); err != nil || b == false {
+		t.Fatal("Project has tracker error:", err, s, b)
+	}
+
+	if b, s, err := r.ProjectHasTracker("1", 2); err != nil || b == true {
+		t.Fatal("Project has tracker error:", err, s, b)
+	}
+
+	// Tracker is not checked if check is disabled
+	if _, s, err := r.IssueCreate(IssueCreateObject{ProjectID: 1, TrackerID: 2, Subject: testIssueSubject}); err != nil || creates != 1 {
+		t.Fatal("Issue create error:", err, s)
+	}
+
+	r.SetIssueTrackerCheck(true)
+
+	if _, _, err := r.IssueCreate(IssueCreateObject{ProjectID: 1, TrackerID: 2, Subject: testIssueSubject}); err == nil || creates != 1 {
+		t.Fatal("Issue create error: issue with disabled tracker has been sent:", err)
+	}
+
+	i, s, err := r.IssueCreate(IssueCreateObject{ProjectID: 1, TrackerID: 1, Subject: testIssueSubject})
+	if err != nil || creates != 2 || i.ID != 10 {
+		t.Fatal("Issue create error:", err, s)
+	}
+
+	if projects != 1 || includes[0] != "trackers" {
+		t.Fatal("Issue create error: project trackers have not been cached:", projects, includes)
+	}
+
+	t.Logf("Issue create tracker check: success")
+}
+
+func TestIssuesDoneRatio(t *testing.T) {
+
+	issue := func(estimate float64, ratio, status int) IssueObject {
+		return IssueObject{
+			EstimatedHours: estimate,
+			DoneRatio:      ratio,
+			Status:         IDName{ID: status},
+		}
+	}
+
+	// Status 5 is closed
+	closed := map[int]bool{1: false, 5: true}
+
+	for _, e := range []struct {
+		name     string
+		issues   []IssueObject
+		expected float64
+		size     int
+	}{
+		{"estimated", []IssueObject{issue(2, 50, 1), issue(6, 0, 1)}, 12.5, 2},
+		{"estimated done", []IssueObject{issue(2, 100, 1), issue(6, 50, 1)}, 62.5, 2},
+		{"average estimate", []IssueObject{issue(4, 100, 1), issue(0, 0, 1), issue(0, 50, 1)}, 50, 3},
+		{"not estimated", []IssueObject{issue(0, 20, 1), issue(0, 60, 1)}, 40, 2},
+		{"closed", []IssueObject{issue(1, 0, 5), issue(3, 0, 1)}, 25, 2},
+		{"closed not estimated", []IssueObject{issue(0, 30, 5), issue(0, 0, 1)}, 50, 2},
+		{"empty", nil, 0, 0},
+	} {
+
+		ratio, size := issuesDoneRatio(e.issues, closed)
+		if ratio != e.expected || size != e.size {
+			t.Fatalf("Issues done ratio error: incorrect result for `%s` case: %v (expected: %v), sample size %d (expected: %d)", e.name, ratio, e.expected, size, e.size)
+		}
+	}
+
+	t.Logf("Issues done ratio: success")
+}
+
+func TestIssueCycleTime(t *testing.T) {
+
+	var r Context
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"issues":[` +
+			`{"id":1,"created_on":"2022-07-01T10:00:00Z","closed_on":"2022-07-03T16:30:00Z"},` +
+			`{"id":2,"created_on":"2022-07-01T10:00:00Z","closed_on":null},` +
+			`{"id":3,"created_on":"2022-07-01T10:00:00Z","closed_on":"2022-07-03"}` +
+			`],"total_count":3,"offset":0,"limit":100}`))
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+
+	issues, s, err := r.IssuesAllGet(IssueAllGetRequest{})
+	if err != nil || len(issues.Issues) != 3 {
+		t.Fatal("Issues all get error:", err, s)
+	}
+
+	for n, e := range []struct {
+		duration time.Duration
+		ok       bool
+	}{
+		{54*time.Hour + 30*time.Minute, true},
+		{0, false},
+		{0, false},
+	} {
+
+		d, b := issues.Issues[n].CycleTime()
+		if d != e.duration || b != e.ok {
+			t.Fatalf("Issue cycle time error: incorrect result for issue %d: %v, %v", issues.Issues[n].ID, d, b)
+		}
+	}
+
+	t.Logf("Issue cycle time: success")
+}
+
+func TestIssueLatestNoteGet(t *testing.T) {
+
+	var r Context
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if req.URL.Query().Get("include") != "journals" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		switch req.URL.Path {
+		case "/issues/1.json":
+			w.Write([]byte(`{"issue":{"id":1,"journals":[` +
+				`{"id":1,"user":{"id":5},"notes":"First note","created_on":"2022-07-01T10:00:00Z"},` +
+				`{"id":2,"user":{"id":6},"notes":"Private note","private_notes":true,"created_on":"2022-07-02T10:00:00Z"},` +
+				`{"id":3,"user":{"id":5},"notes":"","created_on":"2022-07-03T10:00:00Z","details":[{"property":"attr","name":"status_id","old_value":"1","new_value":"2"}]},` +
+				`{"id":4,"user":{"id":5},"notes":" \n ","created_on":"2022-07-04T10:00:00Z"}]}}`))
+		case "/issues/2.json":
+			w.Write([]byte(`{"issue":{"id":2,"journals":[{"id":5,"user":{"id":5},"notes":"","created_on":"2022-07-01T10:00:00Z"}]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+
+	j, s, err := r.IssueLatestNoteGet(1)
+	if err != nil {
+		t.Fatal("Issue latest note get error:", err, s)
+	}
+
+	if j.ID != 2 || j.Notes != "Private note" || j.PrivateNotes != true || j.User.ID != 6 {
+		t.Fatalf("Issue latest note get error: incorrect journal: %+v", j)
+	}
+
+	if _, s, err := r.IssueLatestNoteGet(2); IsNotFound(err) == false {
+		t.Fatal("Issue latest note get error: not found error expected for issue without notes:", err, s)
+	}
+
+	t.Logf("Issue latest note get: success")
+}
+
+func TestIssueFiltersMyProjects(t *testing.T) {
+
+	var (
+		r    Context
+		mu   sync.Mutex
+		hits = make(map[string]int)
+	)
+
+	projects := map[string]string{
+		"key-a": `{"project":{"id":1}},{"project":{"id":2}}`,
+		"key-b": `{"project":{"id":3}}`,
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		key := req.Header.Get("X-Redmine-API-Key")
+
+		mu.Lock()
+		hits[key]++
+		mu.Unlock()
+
+		m, b := projects[key]
+		if req.URL.Path != "/users/current.json" || b == false {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"user":{"id":1,"memberships":[` + m + `]}}`))
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+	r.SetAPIKey("key-a")
+	r.SetMetadataCache(time.Minute)
+
+	b := r.With(WithAPIKey("key-b"))
+
+	for n := 0; n < 2; n++ {
+
+		f, s, err := r.IssueFiltersMyProjects(IssueGetRequestFilters{UpdatedByID: IssueFilterMe})
+		if err != nil {
+			t.Fatal("Issue filters my projects error:", err, s)
+		}
+
+		if reflect.DeepEqual(f.ProjectIDs, []int{1, 2}) == false || f.UpdatedByID != IssueFilterMe {
+			t.Fatalf("Issue filters my projects error: incorrect filters: %+v", f)
+		}
+
+		f, s, err = b.IssueFiltersMyProjects(IssueGetRequestFilters{})
+		if err != nil {
+			t.Fatal("Issue filters my projects error:", err, s)
+		}
+
+		if reflect.DeepEqual(f.ProjectIDs, []int{3}) == false {
+			t.Fatalf("Issue filters my projects error: incorrect filters for derived context: %+v", f)
+		}
+	}
+
+	if hits["key-a"] != 1 || hits["key-b"] != 1 {
+		t.Fatalf("Issue filters my projects error: incorrect current user requests: %v", hits)
+	}
+
+	r.cache.mu.Lock()
+	for k := range r.cache.entries {
+		if strings.Contains(k, "key-a") == true || strings.Contains(k, "key-b") == true {
+			t.Errorf("Issue filters my projects error: API key within cache key: %s", k)
+		}
+	}
+	r.cache.mu.Unlock()
+
+	t.Logf("Issue filters my projects: success")
+}
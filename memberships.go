@@ -1,6 +1,7 @@
 package redmine
 
 import (
+	"errors"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -21,14 +22,14 @@ type MembershipObject struct {
 type MembershipRoleObject struct {
 	ID        int    `json:"id"`
 	Name      string `json:"name"`
-	Inherited bool   `json:"inherited"`
+	Inherited bool   `json:"inherited"` // role is inherited from parent project or group membership
 }
 
 /* Add */
 
 // MembershipAddObject struct used for project memberships add operations
 type MembershipAddObject struct {
-	UserID  int   `json:"user_id"`
+	UserID  int   `json:"user_id"` // user or group ID
 	RoleIDs []int `json:"role_ids"`
 }
 
@@ -67,6 +68,15 @@ type membershipAdd struct {
 	Membership MembershipAddObject `json:"membership"`
 }
 
+type membershipAddMulti struct {
+	Membership membershipAddMultiObject `json:"membership"`
+}
+
+type membershipAddMultiObject struct {
+	UserIDs []int `json:"user_ids"`
+	RoleIDs []int `json:"role_ids"`
+}
+
 type membershipUpdate struct {
 	Membership MembershipUpdateObject `json:"membership"`
 }
@@ -76,38 +86,32 @@ type membershipUpdate struct {
 // see: http://www.redmine.org/projects/redmine/wiki/Rest_Memberships#GET
 func (r *Context) MembershipAllGet(projectID string) (MembershipResult, int, error) {
 
-	var (
-		membership     MembershipResult
-		offset, status int
-	)
-
-	m := MembershipMultiGetRequest{
-		Limit: limitDefault,
-	}
+	var membership MembershipResult
 
-	for {
+	m := MembershipMultiGetRequest{}
 
-		m.Offset = offset
+	pages, total, status, err := r.paginate(func(offset, limit int) (interface{}, int, int, int, error) {
 
-		m, s, err := r.MembershipMultiGet(projectID, m)
-		if err != nil {
-			return membership, s, err
-		}
+		req := m
+		req.Offset = offset
+		req.Limit = limit
 
-		status = s
+		p, s, err := r.MembershipMultiGet(projectID, req)
 
-		membership.Memberships = append(membership.Memberships, m.Memberships...)
+		return p.Memberships, len(p.Memberships), p.TotalCount, s, err
+	})
 
-		if offset+m.Limit >= m.TotalCount {
-			membership.TotalCount = m.TotalCount
-			membership.Limit = m.TotalCount
-
-			break
-		}
+	for _, p := range pages {
+		membership.Memberships = append(membership.Memberships, p.([]MembershipObject)...)
+	}
 
-		offset += m.Limit
+	if err != nil {
+		return membership, status, err
 	}
 
+	membership.TotalCount = total
+	membership.Limit = total
+
 	return membership, status, nil
 }
 
@@ -129,6 +133,11 @@ func (r *Context) MembershipMultiGet(projectID string, request MembershipMultiGe
 
 	s, err := r.Get(&m, ur, http.StatusOK)
 
+	// Redmine returns empty list if offset is beyond total count
+	if err == nil && m.Memberships == nil {
+		m.Memberships = []MembershipObject{}
+	}
+
 	return m, s, err
 }
 
@@ -148,22 +157,88 @@ func (r *Context) MembershipSingleGet(membershipID int) (MembershipObject, int,
 	return m.Membership, status, err
 }
 
-// MembershipAdd adds new member to project with specified ID
+// MembershipAdd adds new member to project with specified ID.
+// Member may be either a user or a group, its ID must be set in `UserID` field
 //
 // see: http://www.redmine.org/projects/redmine/wiki/Rest_Memberships#POST
 func (r *Context) MembershipAdd(projectID string, membership MembershipAddObject) (MembershipObject, int, error) {
 
 	var m membershipSingleResult
 
+	if len(membership.RoleIDs) == 0 {
+		return m.Membership, 0, errors.New("membership add error: at least one role must be specified")
+	}
+
 	ur := url.URL{
 		Path: "/projects/" + projectID + "/memberships.json",
 	}
 
-	status, err := r.Post(membershipAdd{Membership: membership}, &m, ur, http.StatusCreated)
+	status, err := r.Post(membershipAdd{Membership: membership}, &m, ur, http.StatusCreated, http.StatusOK)
 
 	return m.Membership, status, err
 }
 
+// MembershipAddMulti adds several members (users and/or groups) with the same roles to project with specified ID.
+// All members are sent within one request (`user_ids`). Redmine responds with the first created membership only,
+// so project memberships are requested after that and created ones are returned in order of specified IDs.
+// If some of members can not be added Redmine responds with validation error, others are added anyway
+//
+// see: http://www.redmine.org/projects/redmine/wiki/Rest_Memberships#POST
+func (r *Context) MembershipAddMulti(projectID string, memberIDs []int, roleIDs []int) ([]MembershipObject, int, error) {
+
+	memberships := []MembershipObject{}
+
+	if len(roleIDs) == 0 {
+		return memberships, 0, errors.New("membership add error: at least one role must be specified")
+	}
+
+	if len(memberIDs) == 0 {
+		return memberships, 0, nil
+	}
+
+	ur := url.URL{
+		Path: "/projects/" + projectID + "/memberships.json",
+	}
+
+	status, err := r.Post(membershipAddMulti{
+		Membership: membershipAddMultiObject{
+			UserIDs: memberIDs,
+			RoleIDs: roleIDs,
+		},
+	}, nil, ur, http.StatusCreated, http.StatusOK)
+	if err != nil || r.dryRunEnabled() == true {
+		return memberships, status, err
+	}
+
+	m, s, err := r.readPrimary().MembershipAllGet(projectID)
+	if err != nil {
+		return memberships, s, err
+	}
+
+	created := make(map[int]MembershipObject)
+	for _, e := range m.Memberships {
+		if e.Group.ID != 0 {
+			created[e.Group.ID] = e
+		} else {
+			created[e.User.ID] = e
+		}
+	}
+
+	seen := make(map[int]bool)
+	for _, id := range memberIDs {
+
+		e, b := created[id]
+		if b == false || seen[id] == true {
+			continue
+		}
+
+		seen[id] = true
+		memberships = append(memberships, e)
+	}
+
+	return memberships, status, nil
+}
+
 // MembershipUpdate updates project membership with specified ID
 //
 // see: http://www.redmine.org/projects/redmine/wiki/Rest_Memberships#PUT
@@ -173,7 +248,7 @@ func (r *Context) MembershipUpdate(membershipID int, membership MembershipUpdate
 		Path: "/memberships/" + strconv.Itoa(membershipID) + ".json",
 	}
 
-	status, err := r.Put(membershipUpdate{Membership: membership}, nil, ur, http.StatusNoContent)
+	status, err := r.Put(membershipUpdate{Membership: membership}, nil, ur, http.StatusNoContent, http.StatusOK)
 
 	return status, err
 }
@@ -187,7 +262,44 @@ func (r *Context) MembershipDelete(membershipID int) (int, error) {
 		Path: "/memberships/" + strconv.Itoa(membershipID) + ".json",
 	}
 
-	status, err := r.Del(nil, nil, ur, http.StatusNoContent)
+	status, err := r.Del(nil, nil, ur, http.StatusNoContent, http.StatusOK)
 
 	return status, err
 }
+
+// MembershipsDirect returns memberships with directly assigned roles only. Inherited roles (from parent project
+// or group membership) are removed, memberships without directly assigned roles are skipped
+func MembershipsDirect(memberships []MembershipObject) []MembershipObject {
+	return membershipsRolesFilter(memberships, false)
+}
+
+// MembershipsInherited returns memberships with inherited roles only (from parent project or group membership).
+// Directly assigned roles are removed, memberships without inherited roles are skipped
+func MembershipsInherited(memberships []MembershipObject) []MembershipObject {
+	return membershipsRolesFilter(memberships, true)
+}
+
+// membershipsRolesFilter returns memberships with roles matching specified inherited flag
+func membershipsRolesFilter(memberships []MembershipObject, inherited bool) []MembershipObject {
+
+	ms := []MembershipObject{}
+
+	for _, m := range memberships {
+
+		var roles []MembershipRoleObject
+		for _, r := range m.Roles {
+			if r.Inherited == inherited {
+				roles = append(roles, r)
+			}
+		}
+
+		if len(roles) == 0 {
+			continue
+		}
+
+		m.Roles = roles
+		ms = append(ms, m)
+	}
+
+	return ms
+}
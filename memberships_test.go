@@ -1,7 +1,12 @@
 package redmine
 
 import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"reflect"
 	"strconv"
 	"testing"
 )
@@ -118,3 +123,88 @@ func testMembershipSingleGet(t *testing.T, r Context, id, roleID int) {
 
 	t.Fatal("Membership get error: can't find role in added membership")
 }
+
+func TestMembershipsInheritance(t *testing.T) {
+
+	memberships := []MembershipObject{
+		{ID: 1, Roles: []MembershipRoleObject{{ID: 3}, {ID: 4, Inherited: true}}},
+		{ID: 2, Roles: []MembershipRoleObject{{ID: 3, Inherited: true}}},
+		{ID: 3, Roles: []MembershipRoleObject{{ID: 5}}},
+	}
+
+	d := MembershipsDirect(memberships)
+	if len(d) != 2 || d[0].ID != 1 || len(d[0].Roles) != 1 || d[0].Roles[0].ID != 3 || d[1].ID != 3 {
+		t.Fatalf("Memberships direct error: incorrect result: %+v", d)
+	}
+
+	i := MembershipsInherited(memberships)
+	if len(i) != 2 || i[0].ID != 1 || len(i[0].Roles) != 1 || i[0].Roles[0].ID != 4 || i[1].ID != 2 {
+		t.Fatalf("Memberships inherited error: incorrect result: %+v", i)
+	}
+
+	if len(memberships[0].Roles) != 2 {
+		t.Fatal("Memberships inheritance error: source memberships have been changed")
+	}
+
+	t.Logf("Memberships inheritance: success")
+}
+
+func TestMembershipAddMulti(t *testing.T) {
+
+	var (
+		r        Context
+		requests []string
+		bodies   []map[string]map[string][]int
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		requests = append(requests, req.Method+" "+req.URL.Path)
+
+		if req.URL.Path != "/projects/test/memberships.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch req.Method {
+		case http.MethodPost:
+			b, _ := ioutil.ReadAll(req.Body)
+			m := make(map[string]map[string][]int)
+			json.Unmarshal(b, &m)
+			bodies = append(bodies, m)
+			// Only the first created membership is returned
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"membership":{"id":11,"user":{"id":5}}}`))
+		case http.MethodGet:
+			w.Write([]byte(`{"memberships":[` +
+				`{"id":10,"user":{"id":6},"roles":[{"id":4}]},` +
+				`{"id":11,"user":{"id":5},"roles":[{"id":3}]},` +
+				`{"id":12,"group":{"id":9},"roles":[{"id":3}]}` +
+				`],"total_count":3,"offset":0,"limit":100}`))
+		}
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+
+	if _, _, err := r.MembershipAddMulti("test", []int{9, 5}, nil); err == nil || len(requests) != 0 {
+		t.Fatal("Membership add multi error: members without roles have been sent:", err, requests)
+	}
+
+	m, s, err := r.MembershipAddMulti("test", []int{9, 5}, []int{3})
+	if err != nil || s != http.StatusCreated {
+		t.Fatal("Membership add multi error:", err, s)
+	}
+
+	if len(bodies) != 1 || reflect.DeepEqual(bodies[0]["membership"]["user_ids"], []int{9, 5}) == false || reflect.DeepEqual(bodies[0]["membership"]["role_ids"], []int{3}) == false {
+		t.Fatal("Membership add multi error: incorrect request body:", bodies)
+	}
+
+	if len(m) != 2 || m[0].ID != 12 || m[0].Group.ID != 9 || m[1].ID != 11 || m[1].User.ID != 5 {
+		t.Fatalf("Membership add multi error: incorrect created memberships: %+v", m)
+	}
+
+	t.Logf("Membership add multi: success")
+}
@@ -0,0 +1,124 @@
+package redmine
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+/* Get */
+
+// NewsObject struct used for news get operations
+type NewsObject struct {
+	ID          int    `json:"id"`
+	Project     IDName `json:"project"`
+	Author      IDName `json:"author"`
+	Title       string `json:"title"`
+	Summary     string `json:"summary"`
+	Description string `json:"description"`
+	CreatedOn   string `json:"created_on"`
+}
+
+/* Requests */
+
+// NewsMultiGetRequest contains data for making request to get limited news count
+type NewsMultiGetRequest struct {
+	Offset int
+	Limit  int
+}
+
+/* Results */
+
+// NewsResult stores news requests processing result
+type NewsResult struct {
+	News       []NewsObject `json:"news"`
+	TotalCount int          `json:"total_count"`
+	Offset     int          `json:"offset"`
+	Limit      int          `json:"limit"`
+}
+
+// NewsAllGetByProject gets info for all news of project with specified ID.
+// News are sorted by creation date in descending order
+//
+// see: https://www.redmine.org/projects/redmine/wiki/Rest_News#GET
+func (r *Context) NewsAllGetByProject(projectID string) (NewsResult, int, error) {
+
+	var news NewsResult
+
+	m := NewsMultiGetRequest{}
+
+	pages, total, status, err := r.paginate(func(offset, limit int) (interface{}, int, int, int, error) {
+
+		req := m
+		req.Offset = offset
+		req.Limit = limit
+
+		p, s, err := r.NewsMultiGetByProject(projectID, req)
+
+		return p.News, len(p.News), p.TotalCount, s, err
+	})
+
+	for _, p := range pages {
+		news.News = append(news.News, p.([]NewsObject)...)
+	}
+
+	if err != nil {
+		return news, status, err
+	}
+
+	news.TotalCount = total
+	news.Limit = total
+
+	return news, status, nil
+}
+
+// NewsMultiGetByProject gets info for multiple news of project with specified ID.
+// News are sorted by creation date in descending order
+//
+// see: https://www.redmine.org/projects/redmine/wiki/Rest_News#GET
+func (r *Context) NewsMultiGetByProject(projectID string, request NewsMultiGetRequest) (NewsResult, int, error) {
+
+	var n NewsResult
+
+	urlParams := url.Values{}
+	urlParams.Add("offset", strconv.Itoa(request.Offset))
+	urlParams.Add("limit", strconv.Itoa(request.Limit))
+
+	ur := url.URL{
+		Path:     "/projects/" + projectID + "/news.json",
+		RawQuery: urlParams.Encode(),
+	}
+
+	s, err := r.Get(&n, ur, http.StatusOK)
+
+	// Redmine returns empty list if offset is beyond total count
+	if err == nil && n.News == nil {
+		n.News = []NewsObject{}
+	}
+
+	return n, s, err
+}
+
+// NewsLatestGet gets the latest news of project with specified ID and total news count of project.
+// Only one news is requested, Redmine always sorts news by creation date in descending order.
+// Nil is returned if project has no news or news are not available: Redmine responds with `403 Forbidden` if news
+// module is disabled (or current user is not permitted to view news) and with `404 Not Found` for unknown project,
+// so misspelled project ID also gives nil. Returned status allows to tell these cases apart. Other errors are returned as is
+func (r *Context) NewsLatestGet(projectID string) (*NewsObject, int, int, error) {
+
+	n, status, err := r.NewsMultiGetByProject(projectID, NewsMultiGetRequest{
+		Limit: 1,
+	})
+	if err != nil {
+		if IsNotFound(err) == true || IsForbidden(err) == true {
+			return nil, 0, status, nil
+		}
+		return nil, 0, status, err
+	}
+
+	if len(n.News) == 0 {
+		return nil, n.TotalCount, status, nil
+	}
+
+	return &n.News[0], n.TotalCount, status, nil
+}
@@ -0,0 +1,72 @@
+package redmine
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewsLatestGet(t *testing.T) {
+
+	var (
+		r      Context
+		limits []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch req.URL.Path {
+		case "/projects/test/news.json":
+			limits = append(limits, req.URL.Query().Get("limit"))
+			w.Write([]byte(`{"news":[{"id":3,"title":"Release 2.0","created_on":"2022-07-02T10:00:00Z"}],"total_count":3,"offset":0,"limit":1}`))
+		case "/projects/empty/news.json":
+			w.Write([]byte(`{"news":[],"total_count":0,"offset":0,"limit":1}`))
+		case "/projects/nonews/news.json":
+			w.WriteHeader(http.StatusForbidden)
+		case "/projects/broken/news.json":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+
+	n, total, s, err := r.NewsLatestGet("test")
+	if err != nil {
+		t.Fatal("News latest get error:", err, s)
+	}
+
+	if n == nil || n.ID != 3 || total != 3 {
+		t.Fatalf("News latest get error: incorrect result (news: %+v, total: %d)", n, total)
+	}
+
+	if len(limits) != 1 || limits[0] != "1" {
+		t.Fatal("News latest get error: incorrect limit:", limits)
+	}
+
+	for p, status := range map[string]int{
+		"empty":   http.StatusOK,
+		"nonews":  http.StatusForbidden,
+		"unknown": http.StatusNotFound,
+	} {
+
+		n, total, s, err := r.NewsLatestGet(p)
+		if err != nil {
+			t.Fatal("News latest get error:", err, s)
+		}
+
+		if n != nil || total != 0 || s != status {
+			t.Fatalf("News latest get error: nil expected for project `%s` (status: %d)", p, s)
+		}
+	}
+
+	if _, _, s, err := r.NewsLatestGet("broken"); err == nil || s != http.StatusInternalServerError {
+		t.Fatal("News latest get error: server error expected:", err, s)
+	}
+
+	t.Logf("News latest get: success")
+}
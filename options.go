@@ -0,0 +1,47 @@
+package redmine
+
+// Option is used to override Context settings in derived Context (see `With()`)
+type Option func(*Context)
+
+// WithAPIKey overrides Redmine API key. Re-authentication (see `SetOnUnauthorized()`) is disabled for derived Context
+func WithAPIKey(apiKey string) Option {
+	return func(r *Context) {
+		r.apiKey = apiKey
+		r.reauth = nil
+	}
+}
+
+// WithSwitchUser overrides login of user to impersonate (see `SetSwitchUser()`)
+func WithSwitchUser(login string) Option {
+	return func(r *Context) {
+		r.switchUser = login
+	}
+}
+
+// WithLanguage overrides preferred language of Redmine responses (see `SetLanguage()`)
+func WithLanguage(language string) Option {
+	return func(r *Context) {
+		r.language = language
+	}
+}
+
+// WithoutRawSuffix disables automatic `.json` suffix for paths of raw requests (see `RawGet()`)
+func WithoutRawSuffix() Option {
+	return func(r *Context) {
+		r.rawSuffixDisabled = true
+	}
+}
+
+// With returns a copy of Context with specified settings overridden. Original Context is not changed.
+// Copy shares metadata cache and re-authentication state (API key refreshed via `SetOnUnauthorized()`) with original one,
+// so it is safe to use them concurrently as long as neither of them is being reconfigured via setters
+func (r *Context) With(opts ...Option) *Context {
+
+	c := *r
+
+	for _, o := range opts {
+		o(&c)
+	}
+
+	return &c
+}
@@ -0,0 +1,49 @@
+package redmine
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWith(t *testing.T) {
+
+	var (
+		r       Context
+		headers []http.Header
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		headers = append(headers, req.Header.Clone())
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"user":{"id":5,"login":"jsmith"}}`))
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+	r.SetAPIKey("admin-key")
+	r.SetLanguage("en")
+
+	c := r.With(WithAPIKey("user-key"), WithSwitchUser("jdoe"), WithLanguage("de"))
+
+	for _, e := range []*Context{c, &r} {
+		if _, s, err := e.UserCurrentGet(UserCurrentGetRequest{}); err != nil {
+			t.Fatal("With error: request error:", err, s)
+		}
+	}
+
+	if len(headers) != 2 {
+		t.Fatal("With error: incorrect requests count:", len(headers))
+	}
+
+	if headers[0].Get("X-Redmine-API-Key") != "user-key" || headers[0].Get("X-Redmine-Switch-User") != "jdoe" || headers[0].Get("Accept-Language") != "de" {
+		t.Fatal("With error: incorrect derived Context headers:", headers[0])
+	}
+
+	// Original Context is not changed
+	if headers[1].Get("X-Redmine-API-Key") != "admin-key" || headers[1].Get("X-Redmine-Switch-User") != "" || headers[1].Get("Accept-Language") != "en" {
+		t.Fatal("With error: incorrect original Context headers:", headers[1])
+	}
+
+	t.Logf("With: success")
+}
@@ -0,0 +1,157 @@
+package redmine
+
+import (
+	"net/http"
+	"sync"
+)
+
+const concurrencyDefault = 4
+
+// parallel calls `f` for every index within [0, n) using at most `concurrency` goroutines
+// and waits for all calls to complete
+func parallel(n, concurrency int, f func(i int)) {
+
+	if concurrency <= 0 {
+		concurrency = concurrencyDefault
+	}
+
+	var wg sync.WaitGroup
+
+	sem := make(chan struct{}, concurrency)
+
+	for i := 0; i < n; i++ {
+
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			f(i)
+		}(i)
+	}
+
+	wg.Wait()
+}
+
+const (
+	adaptiveThrottlesToShrink = 2 // consecutive throttled responses shrinking concurrency by half
+)
+
+// parallelAdaptive calls `f` for every index within [0, n) the same way as `parallel()` does, but concurrency
+// is adapted to Redmine rate limits: every `429 Too Many Requests` response made via Context passed to `f`
+// counts against the limit, sustained ones halve it, and it grows back by one after as many successive responses
+// without throttling as current concurrency is. Requests retried due to retry policy (see `SetRetryPolicy()`)
+// do not occupy concurrency slot while waiting, so other items are processed meanwhile
+func (r *Context) parallelAdaptive(n, concurrency int, f func(c *Context, i int)) {
+
+	if concurrency <= 0 {
+		concurrency = concurrencyDefault
+	}
+
+	c := *r
+	c.limiter = newAdaptiveLimiter(concurrency)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < n; i++ {
+
+		wg.Add(1)
+		c.limiter.acquire()
+
+		go func(i int) {
+			defer func() {
+				c.limiter.release()
+				wg.Done()
+			}()
+			f(&c, i)
+		}(i)
+	}
+
+	wg.Wait()
+}
+
+// adaptiveLimiter is a semaphore with capacity adapted to Redmine responses (see `parallelAdaptive()`)
+type adaptiveLimiter struct {
+	mu        sync.Mutex
+	cond      *sync.Cond
+	max       int
+	limit     int
+	inUse     int
+	throttles int // successive throttled responses
+	successes int // successive responses without throttling since last limit change
+}
+
+func newAdaptiveLimiter(max int) *adaptiveLimiter {
+
+	l := &adaptiveLimiter{
+		max:   max,
+		limit: max,
+	}
+
+	l.cond = sync.NewCond(&l.mu)
+
+	return l
+}
+
+// acquire waits for free slot and occupies it
+func (l *adaptiveLimiter) acquire() {
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for l.inUse >= l.limit {
+		l.cond.Wait()
+	}
+
+	l.inUse++
+}
+
+// release frees occupied slot
+func (l *adaptiveLimiter) release() {
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inUse--
+	l.cond.Broadcast()
+}
+
+// observe adapts limit to response with specified status
+func (l *adaptiveLimiter) observe(status int) {
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if status == http.StatusTooManyRequests {
+
+		l.successes = 0
+		l.throttles++
+
+		if l.throttles >= adaptiveThrottlesToShrink {
+			l.throttles = 0
+			l.limit /= 2
+			if l.limit < 1 {
+				l.limit = 1
+			}
+		}
+
+		return
+	}
+
+	l.throttles = 0
+
+	if l.limit >= l.max {
+		return
+	}
+
+	l.successes++
+
+	if l.successes >= l.limit {
+		l.successes = 0
+		l.limit++
+		l.cond.Broadcast()
+	}
+}
@@ -0,0 +1,110 @@
+package redmine
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAdaptiveLimiter(t *testing.T) {
+
+	l := newAdaptiveLimiter(8)
+
+	for _, e := range []struct {
+		status int
+		count  int
+		limit  int
+	}{
+		{http.StatusTooManyRequests, 1, 8},
+		{http.StatusTooManyRequests, 1, 4},
+		{http.StatusTooManyRequests, 4, 1},
+		{http.StatusTooManyRequests, 2, 1},
+		{http.StatusNoContent, 1, 2},
+		{http.StatusNoContent, 2, 3},
+		{http.StatusTooManyRequests, 1, 3},
+		{http.StatusNoContent, 3, 4},
+		{http.StatusNoContent, 100, 8},
+	} {
+
+		for i := 0; i < e.count; i++ {
+			l.observe(e.status)
+		}
+
+		if l.limit != e.limit {
+			t.Fatalf("Adaptive limiter error: incorrect limit after %d responses with status %d (expected: %d, got: %d)", e.count, e.status, e.limit, l.limit)
+		}
+	}
+
+	t.Logf("Adaptive limiter: success")
+}
+
+func TestIssuesBulkDeleteThrottled(t *testing.T) {
+
+	var (
+		r                         Context
+		mu                        sync.Mutex
+		requests, inFlight, maxIn int
+		deleted                   = make(map[string]bool)
+	)
+
+	// The first requests are throttled
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		mu.Lock()
+		requests++
+		n := requests
+		inFlight++
+		if inFlight > maxIn {
+			maxIn = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		if n > 6 {
+			deleted[req.URL.Path] = true
+		}
+		mu.Unlock()
+
+		if n <= 6 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+	r.SetRetryPolicy(RetryPolicy{
+		MaxAttempts: 5,
+		Statuses:    []int{http.StatusTooManyRequests},
+		Delay:       time.Millisecond,
+	})
+
+	var ids []int
+	for id := 1; id <= 12; id++ {
+		ids = append(ids, id)
+	}
+
+	res, s, err := r.IssuesBulkDelete(ids, IssuesBulkDeleteRequest{
+		Concurrency: 4,
+	})
+	if err != nil {
+		t.Fatal("Issues bulk delete error:", err, s)
+	}
+
+	if len(res.Deleted) != 12 || len(res.Errors) != 0 || len(deleted) != 12 || requests != 18 {
+		t.Fatalf("Issues bulk delete error: incorrect result (deleted: %d, errors: %v, requests: %d)", len(res.Deleted), res.Errors, requests)
+	}
+
+	if maxIn > 4 {
+		t.Fatal("Issues bulk delete error: concurrency exceeded:", maxIn)
+	}
+
+	t.Logf("Issues bulk delete throttled: success")
+}
@@ -1,9 +1,11 @@
 package redmine
 
 import (
+	"errors"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 )
 
 // ProjectStatus defines project status type
@@ -16,23 +18,56 @@ const (
 	ProjectStatusArchived ProjectStatus = 9
 )
 
+// ProjectAssigneeKind defines kind of project default assignee
+type ProjectAssigneeKind string
+
+// ProjectAssigneeKind const
+const (
+	ProjectAssigneeKindUnknown ProjectAssigneeKind = ""
+	ProjectAssigneeKindUser    ProjectAssigneeKind = "user"
+	ProjectAssigneeKindGroup   ProjectAssigneeKind = "group"
+)
+
+const (
+	projectIdentifierMaxLen      = 100
+	projectIdentifierMaxAttempts = 100
+)
+
 /* Get */
 
 // ProjectObject struct used for projects get operations
 type ProjectObject struct {
-	ID              int                    `json:"id"`
-	Name            string                 `json:"name"`
-	Identifier      string                 `json:"identifier"`
-	Description     string                 `json:"description"`
-	Homepage        string                 `json:"homepage"` // used only: get single project
-	Parent          IDName                 `json:"parent"`
-	Status          ProjectStatus          `json:"status"`
-	CustomFields    []CustomFieldGetObject `json:"custom_fields"`
-	Trackers        []IDName               `json:"trackers"`
-	IssueCategories []IDName               `json:"issue_categories"`
-	EnabledModules  []IDName               `json:"enabled_modules"`
-	CreatedOn       string                 `json:"created_on"`
-	UpdatedOn       string                 `json:"updated_on"`
+	ID                  int                    `json:"id"`
+	Name                string                 `json:"name"`
+	Identifier          string                 `json:"identifier"`
+	Description         string                 `json:"description"`
+	Homepage            string                 `json:"homepage"` // used only: get single project
+	Parent              IDName                 `json:"parent"`
+	Status              ProjectStatus          `json:"status"`
+	CustomFields        []CustomFieldGetObject `json:"custom_fields"`
+	Trackers            []IDName               `json:"trackers"`
+	IssueCategories     []IDName               `json:"issue_categories"`
+	EnabledModules      []IDName               `json:"enabled_modules"`
+	TimeEntryActivities []IDName               `json:"time_entry_activities"` // used only: get single project
+	DefaultAssignee     *IDName                `json:"default_assignee"`      // used only: get single project, nil if not set
+	CreatedOn           string                 `json:"created_on"`
+	UpdatedOn           string                 `json:"updated_on"`
+}
+
+// ProjectRepositoryObject struct used for project repositories get operations
+type ProjectRepositoryObject struct {
+	ID         int    `json:"id"`
+	Identifier string `json:"identifier"`
+	SCM        string `json:"scm"`
+	URL        string `json:"url"`
+	IsDefault  bool   `json:"is_default"`
+}
+
+// ProjectDefaultAssigneeObject struct used for project default assignee get operations
+type ProjectDefaultAssigneeObject struct {
+	ID   int
+	Name string
+	Kind ProjectAssigneeKind
 }
 
 /* Create */
@@ -92,6 +127,16 @@ type ProjectSingleGetRequest struct {
 // ProjectGetRequestFilters contains data for making projects get request
 type ProjectGetRequestFilters struct {
 	Status ProjectStatus
+
+	// Cf filters projects by custom fields values. Filtering is done on client side
+	// after all projects have been fetched, so it is used by `ProjectAllGet()` only
+	Cf []ProjectGetRequestFiltersCf
+}
+
+// ProjectGetRequestFiltersCf contains data for filtering projects by custom field value
+type ProjectGetRequestFiltersCf struct {
+	ID    int
+	Value string
 }
 
 /* Results */
@@ -110,6 +155,10 @@ type projectSingleResult struct {
 	Project ProjectObject `json:"project"`
 }
 
+type projectRepositoriesResult struct {
+	Repositories []ProjectRepositoryObject `json:"repositories"`
+}
+
 type projectCreate struct {
 	Project ProjectCreateObject `json:"project"`
 }
@@ -134,7 +183,9 @@ func (p ProjectStatus) String() string {
 	return s
 }
 
-// ProjectAllGet gets info for all projects
+// ProjectAllGet gets info for all projects.
+// If custom fields filters are specified, all projects are fetched and filtered on client side,
+// total count in result contains filtered projects count
 //
 // see: http://www.redmine.org/projects/redmine/wiki/Rest_Projects#Listing-projects
 //
@@ -144,38 +195,39 @@ func (p ProjectStatus) String() string {
 // * enabled_modules
 func (r *Context) ProjectAllGet(request ProjectAllGetRequest) (ProjectResult, int, error) {
 
-	var (
-		projects       ProjectResult
-		offset, status int
-	)
+	var projects ProjectResult
 
 	m := ProjectMultiGetRequest{
 		Filters:  request.Filters,
 		Includes: request.Includes,
-		Limit:    limitDefault,
 	}
 
-	for {
+	pages, total, status, err := r.paginate(func(offset, limit int) (interface{}, int, int, int, error) {
 
-		m.Offset = offset
+		req := m
+		req.Offset = offset
+		req.Limit = limit
 
-		p, s, err := r.ProjectMultiGet(m)
-		if err != nil {
-			return projects, s, err
-		}
+		p, s, err := r.ProjectMultiGet(req)
 
-		status = s
+		return p.Projects, len(p.Projects), p.TotalCount, s, err
+	})
 
-		projects.Projects = append(projects.Projects, p.Projects...)
+	for _, p := range pages {
+		projects.Projects = append(projects.Projects, p.([]ProjectObject)...)
+	}
 
-		if offset+p.Limit >= p.TotalCount {
-			projects.TotalCount = p.TotalCount
-			projects.Limit = p.TotalCount
+	if err != nil {
+		return projects, status, err
+	}
 
-			break
-		}
+	projects.TotalCount = total
+	projects.Limit = total
 
-		offset += p.Limit
+	if len(request.Filters.Cf) > 0 {
+		projects.Projects = projectsCfFilter(projects.Projects, request.Filters.Cf)
+		projects.TotalCount = len(projects.Projects)
+		projects.Limit = len(projects.Projects)
 	}
 
 	return projects, status, nil
@@ -193,6 +245,10 @@ func (r *Context) ProjectMultiGet(request ProjectMultiGetRequest) (ProjectResult
 
 	var p ProjectResult
 
+	if err := r.includesValidate("project", request.Includes); err != nil {
+		return p, 0, err
+	}
+
 	status := ProjectStatusActive
 	if request.Filters.Status != 0 {
 		status = request.Filters.Status
@@ -213,6 +269,11 @@ func (r *Context) ProjectMultiGet(request ProjectMultiGetRequest) (ProjectResult
 
 	s, err := r.Get(&p, ur, http.StatusOK)
 
+	// Redmine returns empty list if offset is beyond total count
+	if err == nil && p.Projects == nil {
+		p.Projects = []ProjectObject{}
+	}
+
 	return p, s, err
 }
 
@@ -229,6 +290,10 @@ func (r *Context) ProjectSingleGet(id string, request ProjectSingleGetRequest) (
 
 	var p projectSingleResult
 
+	if err := r.includesValidate("project", request.Includes); err != nil {
+		return p.Project, 0, err
+	}
+
 	urlParams := url.Values{}
 
 	// Preparing includes
@@ -244,18 +309,153 @@ func (r *Context) ProjectSingleGet(id string, request ProjectSingleGetRequest) (
 	return p.Project, status, err
 }
 
-// ProjectCreate creates new project
+// ProjectTimeEntryActivitiesGet gets time entry activities enabled for project with specified ID.
+// Result is stored in metadata cache if it enabled
+func (r *Context) ProjectTimeEntryActivitiesGet(id string) ([]IDName, int, error) {
+
+	v, status, err := r.cached("project_time_entry_activities:"+id, func() (interface{}, int, error) {
+
+		p, s, err := r.ProjectSingleGet(id, ProjectSingleGetRequest{
+			Includes: []string{"time_entry_activities"},
+		})
+
+		return p.TimeEntryActivities, s, err
+	})
+	if err != nil {
+		return nil, status, err
+	}
+
+	return v.([]IDName), status, nil
+}
+
+// ProjectTrackersGet gets trackers enabled for project with specified ID.
+// Result is stored in metadata cache if it enabled
+func (r *Context) ProjectTrackersGet(id string) ([]IDName, int, error) {
+
+	v, status, err := r.cached("project_trackers:"+id, func() (interface{}, int, error) {
+
+		p, s, err := r.ProjectSingleGet(id, ProjectSingleGetRequest{
+			Includes: []string{"trackers"},
+		})
+
+		return p.Trackers, s, err
+	})
+	if err != nil {
+		return nil, status, err
+	}
+
+	return v.([]IDName), status, nil
+}
+
+// ProjectHasTracker checks whether tracker with specified ID is enabled for project with specified ID.
+// Project trackers are stored in metadata cache if it enabled
+func (r *Context) ProjectHasTracker(id string, trackerID int) (bool, int, error) {
+
+	trackers, status, err := r.ProjectTrackersGet(id)
+	if err != nil {
+		return false, status, err
+	}
+
+	for _, t := range trackers {
+		if t.ID == trackerID {
+			return true, status, nil
+		}
+	}
+
+	return false, status, nil
+}
+
+// ProjectDefaultAssigneeGet gets default assignee for project with specified ID. Returns nil if project has no default assignee.
+// Redmine does not provide assignee kind, so it is resolved by project memberships (user or group).
+// Kind is unknown if assignee is not a project member or memberships are not visible for current user
+func (r *Context) ProjectDefaultAssigneeGet(id string) (*ProjectDefaultAssigneeObject, int, error) {
+
+	p, status, err := r.ProjectSingleGet(id, ProjectSingleGetRequest{})
+	if err != nil {
+		return nil, status, err
+	}
+
+	if p.DefaultAssignee == nil || p.DefaultAssignee.ID == 0 {
+		return nil, status, nil
+	}
+
+	a := ProjectDefaultAssigneeObject{
+		ID:   p.DefaultAssignee.ID,
+		Name: p.DefaultAssignee.Name,
+	}
+
+	m, s, err := r.MembershipAllGet(id)
+	if err != nil {
+		if IsForbidden(err) == true {
+			return &a, status, nil
+		}
+		return nil, s, err
+	}
+
+	for _, e := range m.Memberships {
+
+		if e.Group.ID == a.ID {
+			a.Kind = ProjectAssigneeKindGroup
+			break
+		}
+
+		if e.User.ID == a.ID {
+			a.Kind = ProjectAssigneeKindUser
+			break
+		}
+	}
+
+	return &a, s, nil
+}
+
+// ProjectRepositoriesGet gets repositories configured for project with specified ID
+//
+// Note: Redmine core API does not provide project repositories. This method requires a plugin
+// exposing `GET /projects/:id/repositories.json` endpoint with following response format:
+// `{"repositories": [{"id": 1, "identifier": "main", "scm": "Git", "url": "...", "is_default": true}]}`.
+// Empty list is returned if repository module is disabled for project (Redmine responds with `403 Forbidden`).
+// `404 Not Found` error is returned as is, it means either unknown project or server without such plugin
+func (r *Context) ProjectRepositoriesGet(id string) ([]ProjectRepositoryObject, int, error) {
+
+	var p projectRepositoriesResult
+
+	status, err := r.RawGet("/projects/"+id+"/repositories.json", nil, &p, http.StatusOK)
+	if err != nil {
+		if IsForbidden(err) == true {
+			return []ProjectRepositoryObject{}, status, nil
+		}
+		return nil, status, err
+	}
+
+	if p.Repositories == nil {
+		p.Repositories = []ProjectRepositoryObject{}
+	}
+
+	return p.Repositories, status, nil
+}
+
+// ProjectCreate creates new project.
+// If project identifier is empty it will be generated from project name (see `SlugifyIdentifier()`),
+// numeric suffix is added if generated identifier already in use. Used identifier is available in returned object
 //
 // see: http://www.redmine.org/projects/redmine/wiki/Rest_Projects#Creating-a-project
 func (r *Context) ProjectCreate(project ProjectCreateObject) (ProjectObject, int, error) {
 
 	var p projectSingleResult
 
+	if project.Identifier == "" {
+		id, status, err := r.projectIdentifierGenerate(project.Name)
+		if err != nil {
+			return p.Project, status, err
+		}
+		project.Identifier = id
+	}
+
 	ur := url.URL{
 		Path: "/projects.json",
 	}
 
-	status, err := r.Post(projectCreate{Project: project}, &p, ur, http.StatusCreated)
+	status, err := r.Post(projectCreate{Project: project}, &p, ur, http.StatusCreated, http.StatusOK)
 
 	return p.Project, status, err
 }
@@ -269,7 +469,7 @@ func (r *Context) ProjectUpdate(id string, project ProjectUpdateObject) (int, er
 		Path: "/projects/" + id + ".json",
 	}
 
-	status, err := r.Put(projectUpdate{Project: project}, nil, ur, http.StatusNoContent)
+	status, err := r.Put(projectUpdate{Project: project}, nil, ur, http.StatusNoContent, http.StatusOK)
 
 	return status, err
 }
@@ -283,7 +483,117 @@ func (r *Context) ProjectDelete(id string) (int, error) {
 		Path: "/projects/" + id + ".json",
 	}
 
-	status, err := r.Del(nil, nil, ur, http.StatusNoContent)
+	status, err := r.Del(nil, nil, ur, http.StatusNoContent, http.StatusOK)
 
 	return status, err
 }
+
+// SlugifyIdentifier makes valid project identifier from specified name: lowercase latin letters,
+// digits and hyphens, starts with a letter, 1-100 chars length. Other chars are replaced
+// with hyphens, so names without latin letters result in `project` identifier
+func SlugifyIdentifier(name string) string {
+
+	var b strings.Builder
+
+	hyphen := false
+
+	for _, c := range strings.ToLower(name) {
+
+		switch {
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9' && b.Len() > 0:
+			if hyphen == true && b.Len() > 0 {
+				b.WriteRune('-')
+			}
+			b.WriteRune(c)
+			hyphen = false
+		default:
+			hyphen = true
+		}
+	}
+
+	id := b.String()
+	if id == "" {
+		return "project"
+	}
+
+	if len(id) > projectIdentifierMaxLen {
+		id = strings.TrimRight(id[:projectIdentifierMaxLen], "-")
+	}
+
+	return id
+}
+
+// projectIdentifierGenerate generates unused project identifier from specified name
+func (r *Context) projectIdentifierGenerate(name string) (string, int, error) {
+
+	base := SlugifyIdentifier(name)
+
+	for i := 1; i <= projectIdentifierMaxAttempts; i++ {
+
+		id := base
+
+		if i > 1 {
+			suffix := "-" + strconv.Itoa(i)
+			if len(id)+len(suffix) > projectIdentifierMaxLen {
+				id = strings.TrimRight(id[:projectIdentifierMaxLen-len(suffix)], "-")
+			}
+			id += suffix
+		}
+
+		_, status, err := r.ProjectSingleGet(id, ProjectSingleGetRequest{})
+		if status == http.StatusNotFound {
+			return id, status, nil
+		}
+
+		// Identifier is in use (including projects not visible for current user)
+		if err == nil || status == http.StatusForbidden {
+			continue
+		}
+
+		return "", status, err
+	}
+
+	return "", 0, errors.New("project identifier generate error: can't find unused identifier")
+}
+
+// projectsCfFilter returns projects satisfying all specified custom fields filters
+func projectsCfFilter(projects []ProjectObject, filters []ProjectGetRequestFiltersCf) []ProjectObject {
+
+	res := []ProjectObject{}
+
+	for _, p := range projects {
+
+		matched := true
+
+		for _, f := range filters {
+			if projectCfHasValue(p, f.ID, f.Value) == false {
+				matched = false
+				break
+			}
+		}
+
+		if matched == true {
+			res = append(res, p)
+		}
+	}
+
+	return res
+}
+
+func projectCfHasValue(p ProjectObject, id int, value string) bool {
+
+	for _, c := range p.CustomFields {
+
+		if c.ID != id {
+			continue
+		}
+
+		for _, v := range c.Value {
+			if v == value {
+				return true
+			}
+		}
+	}
+
+	return false
+}
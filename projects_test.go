@@ -1,9 +1,14 @@
 package redmine
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 const (
@@ -135,3 +140,267 @@ func testProjectSingleGet(t *testing.T, r Context, id string) {
 
 	t.Logf("Project get: success")
 }
+
+func TestSlugifyIdentifier(t *testing.T) {
+
+	for name, id := range map[string]string{
+		"Test Project":      "test-project",
+		"  2021 Roadmap!! ": "roadmap",
+		"Q3 -- Release_v2":  "q3-release-v2",
+		"Проект":            "project",
+		"":                  "project",
+	} {
+		if s := SlugifyIdentifier(name); s != id {
+			t.Fatalf("Slugify identifier error: incorrect identifier for `%s` (expected: %s, got: %s)", name, id, s)
+		}
+	}
+
+	if s := SlugifyIdentifier(strings.Repeat("a", 150)); len(s) != projectIdentifierMaxLen {
+		t.Fatal("Slugify identifier error: incorrect identifier length", len(s))
+	}
+
+	t.Logf("Slugify identifier: success")
+}
+
+func TestProjectDefaultAssigneeGet(t *testing.T) {
+
+	var r Context
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch req.URL.Path {
+		case "/projects/test.json":
+			w.Write([]byte(`{"project":{"id":1,"identifier":"test","default_assignee":{"id":7,"name":"Developers"}}}`))
+		case "/projects/empty.json":
+			w.Write([]byte(`{"project":{"id":2,"identifier":"empty"}}`))
+		case "/projects/test/memberships.json":
+			w.Write([]byte(`{"memberships":[` +
+				`{"id":1,"user":{"id":5,"name":"John Smith"},"roles":[{"id":3,"name":"Manager"}]},` +
+				`{"id":2,"group":{"id":7,"name":"Developers"},"roles":[{"id":4,"name":"Developer"}]}],` +
+				`"total_count":2,"offset":0,"limit":100}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+
+	a, s, err := r.ProjectDefaultAssigneeGet("test")
+	if err != nil {
+		t.Fatal("Project default assignee get error:", err, s)
+	}
+
+	if a == nil || a.ID != 7 || a.Kind != ProjectAssigneeKindGroup {
+		t.Fatalf("Project default assignee get error: incorrect assignee: %+v", a)
+	}
+
+	a, s, err = r.ProjectDefaultAssigneeGet("empty")
+	if err != nil {
+		t.Fatal("Project default assignee get error:", err, s)
+	}
+
+	if a != nil {
+		t.Fatalf("Project default assignee get error: nil expected, got: %+v", a)
+	}
+
+	t.Logf("Project default assignee get: success")
+}
+
+func TestProjectAllGetCfFilter(t *testing.T) {
+
+	var (
+		r       Context
+		queries []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		queries = append(queries, req.URL.RawQuery)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"projects":[` +
+			`{"id":1,"identifier":"one","custom_fields":[{"id":5,"name":"Team","value":"core"},{"id":6,"name":"Tags","value":["go","api"]}]},` +
+			`{"id":2,"identifier":"two","custom_fields":[{"id":5,"name":"Team","value":"web"},{"id":6,"name":"Tags","value":["go"]}]},` +
+			`{"id":3,"identifier":"three","custom_fields":[{"id":6,"name":"Tags","value":["api"]}]}` +
+			`],"total_count":3,"offset":0,"limit":100}`))
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+
+	p, s, err := r.ProjectAllGet(ProjectAllGetRequest{
+		Filters: ProjectGetRequestFilters{
+			Cf: []ProjectGetRequestFiltersCf{
+				{ID: 6, Value: "api"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal("Projects all get error:", err, s)
+	}
+
+	if len(p.Projects) != 2 || p.Projects[0].ID != 1 || p.Projects[1].ID != 3 || p.TotalCount != 2 {
+		t.Fatalf("Projects all get error: incorrect filtered projects: %+v", p)
+	}
+
+	p, s, err = r.ProjectAllGet(ProjectAllGetRequest{
+		Filters: ProjectGetRequestFilters{
+			Cf: []ProjectGetRequestFiltersCf{
+				{ID: 6, Value: "go"},
+				{ID: 5, Value: "web"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal("Projects all get error:", err, s)
+	}
+
+	if len(p.Projects) != 1 || p.Projects[0].ID != 2 || p.TotalCount != 1 {
+		t.Fatalf("Projects all get error: incorrect filtered projects: %+v", p)
+	}
+
+	p, s, err = r.ProjectAllGet(ProjectAllGetRequest{
+		Filters: ProjectGetRequestFilters{
+			Cf: []ProjectGetRequestFiltersCf{
+				{ID: 6, Value: "absent"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal("Projects all get error:", err, s)
+	}
+
+	if p.Projects == nil || len(p.Projects) != 0 || p.TotalCount != 0 {
+		t.Fatalf("Projects all get error: incorrect result without matched projects: %+v", p)
+	}
+
+	// Custom fields filters are not sent to server
+	for _, q := range queries {
+		if strings.Contains(q, "cf_") == true {
+			t.Fatal("Projects all get error: custom field filter has been sent:", q)
+		}
+	}
+
+	t.Logf("Projects all get with custom fields filter: success")
+}
+
+func TestProjectTimeEntryActivitiesGetCached(t *testing.T) {
+
+	var (
+		r        Context
+		mu       sync.Mutex
+		requests = make(map[string]int)
+		includes []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		mu.Lock()
+		requests[req.URL.Path]++
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch req.URL.Path {
+		case "/issues/1.json":
+			w.Write([]byte(`{"issue":{"id":1,"project":{"id":3,"name":"Test"}}}`))
+		case "/projects/3.json":
+			mu.Lock()
+			includes = append(includes, req.URL.Query().Get("include"))
+			mu.Unlock()
+			w.Write([]byte(`{"project":{"id":3,"time_entry_activities":[{"id":8,"name":"Design"},{"id":9,"name":"Development"}]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+	r.SetMetadataCache(time.Minute)
+
+	for i := 0; i < 2; i++ {
+
+		a, s, err := r.IssueTimeEntryActivitiesGet(1)
+		if err != nil {
+			t.Fatal("Issue time entry activities get error:", err, s)
+		}
+
+		if len(a) != 2 || a[1].ID != 9 || a[1].Name != "Development" {
+			t.Fatalf("Issue time entry activities get error: incorrect activities: %+v", a)
+		}
+	}
+
+	if a, s, err := r.ProjectTimeEntryActivitiesGet("3"); err != nil || len(a) != 2 {
+		t.Fatal("Project time entry activities get error:", err, s)
+	}
+
+	if requests["/issues/1.json"] != 2 || requests["/projects/3.json"] != 1 || includes[0] != "time_entry_activities" {
+		t.Fatal("Project time entry activities get error: activities have not been cached:", requests, includes)
+	}
+
+	// Cache flush makes activities to be requested again
+	r.MetadataCacheFlush()
+
+	if _, s, err := r.ProjectTimeEntryActivitiesGet("3"); err != nil || requests["/projects/3.json"] != 2 {
+		t.Fatal("Project time entry activities get error: activities have not been requested after cache flush:", err, s, requests)
+	}
+
+	t.Logf("Project time entry activities get cached: success")
+}
+
+func TestProjectRepositoriesGet(t *testing.T) {
+
+	var r Context
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch req.URL.Path {
+		case "/projects/test/repositories.json":
+			w.Write([]byte(`{"repositories":[` +
+				`{"id":1,"identifier":"main","scm":"Git","url":"https://git.example.com/main.git","is_default":true},` +
+				`{"id":2,"identifier":"docs","scm":"Subversion","url":"svn://svn.example.com/docs"}]}`))
+		case "/projects/empty/repositories.json":
+			w.Write([]byte(`{"repositories":[]}`))
+		case "/projects/norepo/repositories.json":
+			// Repository module is disabled
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+
+	repos, s, err := r.ProjectRepositoriesGet("test")
+	if err != nil {
+		t.Fatal("Project repositories get error:", err, s)
+	}
+
+	expected := []ProjectRepositoryObject{
+		{ID: 1, Identifier: "main", SCM: "Git", URL: "https://git.example.com/main.git", IsDefault: true},
+		{ID: 2, Identifier: "docs", SCM: "Subversion", URL: "svn://svn.example.com/docs"},
+	}
+
+	if len(repos) != len(expected) || repos[0] != expected[0] || repos[1] != expected[1] {
+		t.Fatalf("Project repositories get error: incorrect repositories: %+v", repos)
+	}
+
+	for p, status := range map[string]int{"empty": http.StatusOK, "norepo": http.StatusForbidden} {
+		repos, s, err := r.ProjectRepositoriesGet(p)
+		if err != nil || repos == nil || len(repos) != 0 || s != status {
+			t.Fatalf("Project repositories get error: empty list expected for project `%s`: %v (%d)", p, err, s)
+		}
+	}
+
+	if _, s, err := r.ProjectRepositoriesGet("unknown"); IsNotFound(err) == false {
+		t.Fatal("Project repositories get error: not found error expected:", err, s)
+	}
+
+	t.Logf("Project repositories get: success")
+}
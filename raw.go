@@ -0,0 +1,48 @@
+package redmine
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// RawGet makes GET request to specified Redmine API path (e.g. `/projects/test/repositories`)
+// and decodes response into `out`. Used to access endpoints not implemented within this package (e.g. provided by plugins).
+//
+// Note: `.json` suffix is appended to all raw requests paths not ending with `.json` or `.xml` (including paths
+// with dots within last segment, e.g. `/projects/test/wiki/v1.2`), use `WithoutRawSuffix()` option for endpoints
+// that don't use the suffix (e.g. attachments downloads)
+func (r *Context) RawGet(p string, params url.Values, out interface{}, statusExpected ...int) (int, error) {
+
+	return r.Get(out, r.rawURL(p, params), statusExpected...)
+}
+
+// RawPost makes POST request with `in` as a JSON body to specified Redmine API path and decodes response into `out`
+func (r *Context) RawPost(p string, params url.Values, in interface{}, out interface{}, statusExpected ...int) (int, error) {
+
+	return r.alter(http.MethodPost, in, out, r.rawURL(p, params), statusExpected)
+}
+
+// RawPut makes PUT request with `in` as a JSON body to specified Redmine API path and decodes response into `out`
+func (r *Context) RawPut(p string, params url.Values, in interface{}, out interface{}, statusExpected ...int) (int, error) {
+
+	return r.alter(http.MethodPut, in, out, r.rawURL(p, params), statusExpected)
+}
+
+// RawDel makes DELETE request to specified Redmine API path and decodes response into `out`
+func (r *Context) RawDel(p string, params url.Values, out interface{}, statusExpected ...int) (int, error) {
+
+	return r.alter(http.MethodDelete, nil, out, r.rawURL(p, params), statusExpected)
+}
+
+func (r *Context) rawURL(p string, params url.Values) url.URL {
+
+	if r.rawSuffixDisabled == false && strings.HasSuffix(p, ".json") == false && strings.HasSuffix(p, ".xml") == false {
+		p += ".json"
+	}
+
+	return url.URL{
+		Path:     p,
+		RawQuery: params.Encode(),
+	}
+}
@@ -0,0 +1,30 @@
+package redmine
+
+import (
+	"testing"
+)
+
+func TestRawURLSuffix(t *testing.T) {
+
+	var r Context
+
+	for _, e := range []struct {
+		r        *Context
+		path     string
+		expected string
+	}{
+		{&r, "/projects/test/repositories", "/projects/test/repositories.json"},
+		{&r, "/projects/test/repositories.json", "/projects/test/repositories.json"},
+		{&r, "/projects/test/wiki/v1.2", "/projects/test/wiki/v1.2.json"},
+		{&r, "/users/john.doe", "/users/john.doe.json"},
+		{&r, "/projects/test/issues.xml", "/projects/test/issues.xml"},
+		{r.With(WithoutRawSuffix()), "/attachments/download/7/report.pdf", "/attachments/download/7/report.pdf"},
+		{r.With(WithoutRawSuffix()), "/sys/fetch_changesets", "/sys/fetch_changesets"},
+	} {
+		if u := e.r.rawURL(e.path, nil); u.Path != e.expected {
+			t.Fatalf("Raw URL error: incorrect path (expected: %s, got: %s)", e.expected, u.Path)
+		}
+	}
+
+	t.Logf("Raw URL suffix: success")
+}
@@ -0,0 +1,122 @@
+package redmine
+
+import (
+	"net/http"
+	"sync"
+)
+
+// UnauthorizedFunc is called when Redmine responds with `401 Unauthorized` (see `SetOnUnauthorized()`).
+// It gets API key the request has been failed with and returns new API key and true if request has to be retried
+type UnauthorizedFunc func(apiKey string) (string, bool)
+
+// reauthState stores API key refreshable by unauthorized func.
+// It is stored within Context by pointer, so Context copies share the same key
+type reauthState struct {
+	mu         sync.Mutex
+	refreshed  *sync.Cond // broadcasted when func call is completed
+	refreshing bool       // func is being called
+	apiKey     string
+	f          UnauthorizedFunc
+}
+
+// SetOnUnauthorized is used to set func called when Redmine responds with `401 Unauthorized` (e.g. to get rotated API key).
+// If func returns new key, failed request is retried once with it and the key is used for all subsequent requests.
+// Nil value disables re-authentication (default).
+//
+// It is safe to use Context concurrently: func is called by one goroutine at a time and other requests are not blocked
+// while it runs. Requests failed with the same key meanwhile wait for func result instead of calling it again, requests
+// failed with already replaced key are retried with current key without calling func. Func must not make requests
+// via the same Context. Re-authentication state is shared with Context copies (see `With()`) until `SetAPIKey()`
+// or this method is called on either of them. Contexts derived with `WithAPIKey()` option do not use re-authentication.
+//
+// Note: request body is buffered in memory to be replayed (including attachments uploads)
+func (r *Context) SetOnUnauthorized(f UnauthorizedFunc) {
+
+	if f == nil {
+		r.reauth = nil
+		return
+	}
+
+	r.reauth = newReauthState(r.apiKey, f)
+}
+
+// newReauthState creates re-authentication state with specified API key and unauthorized func
+func newReauthState(apiKey string, f UnauthorizedFunc) *reauthState {
+
+	s := &reauthState{
+		apiKey: apiKey,
+		f:      f,
+	}
+
+	s.refreshed = sync.NewCond(&s.mu)
+
+	return s
+}
+
+// apiKeyGet returns API key to be used for requests
+func (r *Context) apiKeyGet() string {
+
+	if r.reauth == nil {
+		return r.apiKey
+	}
+
+	r.reauth.mu.Lock()
+	defer r.reauth.mu.Unlock()
+
+	return r.reauth.apiKey
+}
+
+// reauthNeeded checks whether request failed with specified response has to be retried with refreshed API key.
+// Returns true if API key has been refreshed
+func (r *Context) reauthNeeded(res *http.Response, statusExpected []int) bool {
+
+	if r.reauth == nil || res.StatusCode != http.StatusUnauthorized || statusIsExpected(res.StatusCode, statusExpected) == true {
+		return false
+	}
+
+	failed := res.Request.Header.Get("X-Redmine-API-Key")
+
+	r.reauth.mu.Lock()
+	defer r.reauth.mu.Unlock()
+
+	waited := false
+	for r.reauth.refreshing == true {
+		r.reauth.refreshed.Wait()
+		waited = true
+	}
+
+	// Key has already been refreshed by another request
+	if r.reauth.apiKey != failed {
+		return true
+	}
+
+	// Func has just failed to refresh the same key
+	if waited == true {
+		return false
+	}
+
+	r.reauth.refreshing = true
+	defer func() {
+		r.reauth.refreshing = false
+		r.reauth.refreshed.Broadcast()
+	}()
+
+	k, b := r.reauth.call(failed)
+	if b == false || k == "" {
+		return false
+	}
+
+	r.reauth.apiKey = k
+
+	return true
+}
+
+// call calls unauthorized func with specified API key. Must be called with mutex locked,
+// mutex is released while func runs
+func (s *reauthState) call(apiKey string) (string, bool) {
+
+	s.mu.Unlock()
+	defer s.mu.Lock()
+
+	return s.f(apiKey)
+}
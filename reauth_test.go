@@ -0,0 +1,195 @@
+package redmine
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestOnUnauthorized(t *testing.T) {
+
+	var (
+		r     Context
+		mu    sync.Mutex
+		calls int
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		if req.Header.Get("X-Redmine-API-Key") != "new" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"issue":{"id":1}}`))
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+	r.SetAPIKey("old")
+	r.SetOnUnauthorized(func(apiKey string) (string, bool) {
+
+		mu.Lock()
+		calls++
+		mu.Unlock()
+
+		if apiKey != "old" {
+			return "", false
+		}
+
+		return "new", true
+	})
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, s, err := r.IssueCreate(IssueCreateObject{ProjectID: 1, Subject: testIssueSubject}); err != nil {
+				t.Error("On unauthorized error:", err, s)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if calls != 1 {
+		t.Fatal("On unauthorized error: incorrect callback calls count:", calls)
+	}
+
+	// Only one re-authentication attempt is made
+	r.SetAPIKey("revoked")
+
+	if _, s, err := r.IssueSingleGet(1, IssueSingleGetRequest{}); IsUnauthorized(err) == false || calls != 2 {
+		t.Fatal("On unauthorized error: unauthorized error expected:", err, s, calls)
+	}
+
+	t.Logf("On unauthorized: success")
+}
+
+func TestOnUnauthorizedNotBlocking(t *testing.T) {
+
+	var (
+		r     Context
+		mu    sync.Mutex
+		calls int
+	)
+
+	entered := make(chan struct{})
+	release := make(chan struct{})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if req.URL.Path == "/trackers.json" {
+			w.Write([]byte(`{"trackers":[{"id":1,"name":"Bug"}]}`))
+			return
+		}
+
+		if req.Header.Get("X-Redmine-API-Key") != "new" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Write([]byte(`{"issue":{"id":1}}`))
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+	r.SetAPIKey("old")
+	r.SetOnUnauthorized(func(apiKey string) (string, bool) {
+
+		mu.Lock()
+		calls++
+		mu.Unlock()
+
+		close(entered)
+		<-release
+
+		return "new", true
+	})
+
+	errs := make(chan error, 2)
+
+	go func() {
+		_, _, err := r.IssueSingleGet(1, IssueSingleGetRequest{})
+		errs <- err
+	}()
+
+	<-entered
+
+	// Requests are not blocked while func runs
+	done := make(chan error, 1)
+	go func() {
+		_, _, err := r.TrackerAllGet()
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal("On unauthorized error: request error:", err)
+		}
+	case <-time.After(5 * time.Second):
+		close(release)
+		t.Fatal("On unauthorized error: request has been blocked by func")
+	}
+
+	// Request failed with the same key waits for func result
+	go func() {
+		_, _, err := r.IssueSingleGet(1, IssueSingleGetRequest{})
+		errs <- err
+	}()
+
+	close(release)
+
+	for i := 0; i < 2; i++ {
+		if err := <-errs; err != nil {
+			t.Fatal("On unauthorized error: request error:", err)
+		}
+	}
+
+	if calls != 1 {
+		t.Fatal("On unauthorized error: incorrect callback calls count:", calls)
+	}
+
+	t.Logf("On unauthorized not blocking: success")
+}
+
+func TestOnUnauthorizedCopies(t *testing.T) {
+
+	var r Context
+
+	r.SetAPIKey("old")
+	r.SetOnUnauthorized(func(apiKey string) (string, bool) {
+		return "new", true
+	})
+
+	shared := r.With()
+
+	derived := r.With()
+	derived.SetAPIKey("other")
+
+	if r.apiKeyGet() != "old" || shared.apiKeyGet() != "old" || derived.apiKeyGet() != "other" {
+		t.Fatal("On unauthorized error: API key of copies has been changed:", r.apiKeyGet(), shared.apiKeyGet(), derived.apiKeyGet())
+	}
+
+	if derived.reauth == nil || derived.reauth == r.reauth {
+		t.Fatal("On unauthorized error: re-authentication state is still shared after API key change")
+	}
+
+	// Refreshed key is shared with copies
+	r.reauth.apiKey = "new"
+
+	if shared.apiKeyGet() != "new" || derived.apiKeyGet() != "other" {
+		t.Fatal("On unauthorized error: incorrect API key of copies:", shared.apiKeyGet(), derived.apiKeyGet())
+	}
+
+	t.Logf("On unauthorized copies: success")
+}
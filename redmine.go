@@ -1,13 +1,16 @@
 package redmine
 
 import (
+	"bytes"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/mitchellh/mapstructure"
 )
@@ -18,8 +21,23 @@ const (
 
 // Context struct used for store settings to communicate with Redmine API
 type Context struct {
-	endpoint string
-	apiKey   string
+	endpoint              string
+	readEndpoint          string
+	apiKey                string
+	switchUser            string
+	language              string
+	maxResponseBytes      int64
+	cache                 *metadataCache
+	serverVersion         string
+	strictIncludes        bool
+	issueTrackerCheck     bool
+	retry                 *RetryPolicy
+	tracer                Tracer
+	rawSuffixDisabled     bool
+	dryRun                *dryRunLog
+	paginationConcurrency int
+	reauth                *reauthState
+	limiter               *adaptiveLimiter
 }
 
 // IDName used as embedded struct for other structs within package
@@ -32,198 +50,279 @@ type errorsResult struct {
 	Errors []string `json:"errors"`
 }
 
-// SetAPIKey is used to set Redmine API key
+// SetAPIKey is used to set Redmine API key. If re-authentication is enabled (see `SetOnUnauthorized()`)
+// Context keeps using the same unauthorized func but stops sharing refreshed key with its copies,
+// so key of other Contexts is not changed
 func (r *Context) SetAPIKey(apiKey string) {
+
 	r.apiKey = apiKey
-}
 
-// SetEndpoint is used to set Redmine endpoint
-func (r *Context) SetEndpoint(endpoint string) {
-	r.endpoint = endpoint
+	if r.reauth != nil {
+		r.reauth = newReauthState(apiKey, r.reauth.f)
+	}
 }
 
-func (r *Context) Get(out interface{}, uri url.URL, statusExpected int) (int, error) {
+// SetEndpoint is used to set Redmine endpoint (e.g. `https://redmine.example.com` or `https://example.com/redmine`).
+// Endpoint is normalized: trailing slashes, query string and fragment are stripped, base path is preserved.
+// Error is returned if endpoint is not an absolute HTTP(S) URL, in this case endpoint is not changed
+func (r *Context) SetEndpoint(endpoint string) error {
 
-	var er errorsResult
-
-	u := r.endpoint + uri.String()
-
-	// Create request
-	req, err := http.NewRequest(http.MethodGet, u, nil)
+	e, err := endpointNormalize(endpoint)
 	if err != nil {
-		return 0, err
+		return err
 	}
 
-	// Set headers
-	req.Header.Add("X-Redmine-API-Key", r.apiKey)
+	r.endpoint = e
 
-	// Make request
-	res, err := http.DefaultClient.Do(req)
+	return nil
+}
+
+// SetSwitchUser is used to set login of user to impersonate (requires admin API key).
+// Empty value disables impersonation
+//
+// see: https://www.redmine.org/projects/redmine/wiki/Rest_api#User-Impersonation
+func (r *Context) SetSwitchUser(login string) {
+	r.switchUser = login
+}
+
+// SetLanguage is used to set preferred language of Redmine responses (sent as `Accept-Language` header)
+func (r *Context) SetLanguage(language string) {
+	r.language = language
+}
+
+// SetReadEndpoint is used to set Redmine endpoint for read requests (e.g. read-only replica).
+// If set all GET requests are sent to this endpoint, other requests are sent to main endpoint.
+//
+// Note: replica may lag behind primary, so just created or updated records may be not found
+// or be outdated when requested right after write operations. Helpers reading data right after
+// their own writes send these reads to main endpoint: `IssueCreateWithWatchers()`, `IssueAppendDescription()`,
+// `IssueSetPrivate()`, `MembershipAddMulti()`, `WikiUpdate()` (current version on conflict) and `WikiUpsert()`
+//
+// Endpoint is normalized the same way as main one (see `SetEndpoint()`). Empty value disables read endpoint
+func (r *Context) SetReadEndpoint(endpoint string) error {
+
+	if endpoint == "" {
+		r.readEndpoint = ""
+		return nil
+	}
+
+	e, err := endpointNormalize(endpoint)
 	if err != nil {
-		return 0, err
+		return err
 	}
-	defer res.Body.Close()
 
-	dJ := json.NewDecoder(res.Body)
+	r.readEndpoint = e
 
-	if res.StatusCode != statusExpected {
-		if err := dJ.Decode(&er); err != nil {
-			er.Errors = append(er.Errors, fmt.Sprintf("json decode error: %v", err))
-		}
-		er.Errors = append(er.Errors, fmt.Sprintf("unexpected status code has been returned (expected: %d, returned: %d, url: %s, method: %s)", statusExpected, res.StatusCode, u, http.MethodGet))
+	return nil
+}
 
-		err = errors.New(strings.Join(er.Errors, "\n"))
-	} else {
-		if out != nil {
+// readPrimary returns Context sending read requests to main endpoint (see `SetReadEndpoint()`)
+func (r *Context) readPrimary() *Context {
 
-			rawConf := make(map[string]interface{})
+	if r.readEndpoint == "" {
+		return r
+	}
 
-			if err := dJ.Decode(&rawConf); err != nil {
-				return res.StatusCode, fmt.Errorf("json decode error: %v", err)
-			}
+	c := *r
+	c.readEndpoint = ""
 
-			dM, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
-				WeaklyTypedInput: true,
-				Result:           out,
-				TagName:          "json",
-			})
-			if err != nil {
-				return res.StatusCode, fmt.Errorf("mapstructure create decoder error: %v", err)
-			}
+	return &c
+}
 
-			if err := dM.Decode(rawConf); err != nil {
-				return res.StatusCode, fmt.Errorf("mapstructure decode error: %v", err)
-			}
-		}
-	}
+// SetMaxResponseBytes is used to limit the size of Redmine API response body.
+// Zero value (default) means unlimited. Attachments downloads are not affected
+func (r *Context) SetMaxResponseBytes(n int64) {
+	r.maxResponseBytes = n
+}
 
-	return res.StatusCode, err
+// Get makes GET request to Redmine API and decodes response into `out`.
+// Request is considered as successful if response status code is one of `statusExpected`
+// (any 2xx status if `statusExpected` is not specified)
+func (r *Context) Get(out interface{}, uri url.URL, statusExpected ...int) (int, error) {
+
+	return r.request(http.MethodGet, uri, nil, "", out, statusExpected)
 }
 
-func (r *Context) Post(in interface{}, out interface{}, uri url.URL, statusExpected int) (int, error) {
+// Post makes POST request to Redmine API with `in` as a JSON body and decodes response into `out`
+func (r *Context) Post(in interface{}, out interface{}, uri url.URL, statusExpected ...int) (int, error) {
 
 	return r.alter(http.MethodPost, in, out, uri, statusExpected)
 }
 
-func (r *Context) Put(in interface{}, out interface{}, uri url.URL, statusExpected int) (int, error) {
+// Put makes PUT request to Redmine API with `in` as a JSON body and decodes response into `out`
+func (r *Context) Put(in interface{}, out interface{}, uri url.URL, statusExpected ...int) (int, error) {
 
 	return r.alter(http.MethodPut, in, out, uri, statusExpected)
 }
 
-func (r *Context) Del(in interface{}, out interface{}, uri url.URL, statusExpected int) (int, error) {
+// Del makes DELETE request to Redmine API with `in` as a JSON body and decodes response into `out`
+func (r *Context) Del(in interface{}, out interface{}, uri url.URL, statusExpected ...int) (int, error) {
 
 	return r.alter(http.MethodDelete, in, out, uri, statusExpected)
 }
 
-func (r *Context) alter(method string, in interface{}, out interface{}, uri url.URL, statusExpected int) (int, error) {
-
-	var er errorsResult
-
-	u := r.endpoint + uri.String()
+func (r *Context) alter(method string, in interface{}, out interface{}, uri url.URL, statusExpected []int) (int, error) {
 
 	s, err := json.Marshal(in)
 	if err != nil {
 		return 0, err
 	}
-	req, err := http.NewRequest(method, u, strings.NewReader(string(s)))
-	if err != nil {
-		return 0, err
+
+	return r.request(method, uri, strings.NewReader(string(s)), "application/json", out, statusExpected)
+}
+
+func (r *Context) uploadFile(f io.Reader, out interface{}, uri url.URL, statusExpected ...int) (int, error) {
+
+	return r.request(http.MethodPost, uri, f, "application/octet-stream", out, statusExpected)
+}
+
+func (r *Context) request(method string, uri url.URL, body io.Reader, contentType string, out interface{}, statusExpected []int) (int, error) {
+
+	endpoint := r.endpoint
+	if method == http.MethodGet && r.readEndpoint != "" {
+		endpoint = r.readEndpoint
 	}
 
-	// Set headers
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("X-Redmine-API-Key", r.apiKey)
+	u := endpoint + uri.String()
 
-	// Make request
-	res, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return 0, err
+	if s, ok, err := r.dryRunCapture(method, u, body, statusExpected); ok == true {
+		return s, err
+	}
+
+	// Request body is buffered to be replayed on retries
+	var payload []byte
+	if (r.retry != nil || r.reauth != nil) && body != nil {
+		b, err := ioutil.ReadAll(body)
+		if err != nil {
+			return 0, err
+		}
+		payload = b
 	}
-	defer res.Body.Close()
 
-	dJ := json.NewDecoder(res.Body)
+	reauthed := false
 
-	if res.StatusCode != statusExpected {
+	for attempt := 1; ; attempt++ {
 
-		if err := dJ.Decode(&er); err != nil {
-			er.Errors = append(er.Errors, fmt.Sprintf("json decode error: %v", err))
+		if payload != nil {
+			body = bytes.NewReader(payload)
 		}
-		er.Errors = append(er.Errors, fmt.Sprintf("unexpected status code has been returned (expected: %d, returned: %d, url: %s, method: %s)", statusExpected, res.StatusCode, u, method))
 
-		err = errors.New(strings.Join(er.Errors, "\n"))
-	} else {
-		if out != nil {
+		// Create request
+		req, err := http.NewRequest(method, u, body)
+		if err != nil {
+			return 0, err
+		}
 
-			rawConf := make(map[string]interface{})
+		// Set headers
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		r.headersSet(req)
 
-			if err := dJ.Decode(&rawConf); err != nil {
-				return res.StatusCode, fmt.Errorf("json decode error: %v", err)
-			}
+		span := r.traceStart(method, uri.Path)
 
-			dM, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
-				WeaklyTypedInput: true,
-				Result:           out,
-				TagName:          "json",
-			})
-			if err != nil {
-				return res.StatusCode, fmt.Errorf("mapstructure create decoder error: %v", err)
-			}
+		// Make request
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			traceEnd(span, 0, err)
+			return 0, err
+		}
 
-			if err := dM.Decode(rawConf); err != nil {
-				return res.StatusCode, fmt.Errorf("mapstructure decode error: %v", err)
-			}
+		if r.limiter != nil {
+			r.limiter.observe(res.StatusCode)
 		}
-	}
 
-	return res.StatusCode, err
-}
+		// Request is retried once with refreshed API key, it is not counted as an attempt
+		if reauthed == false && r.reauthNeeded(res, statusExpected) == true {
+			res.Body.Close()
+			traceEnd(span, res.StatusCode, fmt.Errorf("request will be retried with refreshed API key"))
+			reauthed = true
+			attempt--
+			continue
+		}
 
-func (r *Context) uploadFile(f io.Reader, out interface{}, uri url.URL, statusExpected int) (int, error) {
+		if r.retry == nil || attempt >= r.retry.MaxAttempts {
+			s, err := r.response(res, r.responseReader(res.Body), out, statusExpected)
+			res.Body.Close()
+			traceEnd(span, s, err)
+			return s, err
+		}
 
-	var er errorsResult
+		data, err := ioutil.ReadAll(r.responseReader(res.Body))
+		res.Body.Close()
+		if err != nil {
+			traceEnd(span, res.StatusCode, err)
+			return res.StatusCode, err
+		}
 
-	u := r.endpoint + uri.String()
+		if r.retry.retryable(res, data) == false {
+			s, err := r.response(res, bytes.NewReader(data), out, statusExpected)
+			traceEnd(span, s, err)
+			return s, err
+		}
 
-	// Create request
-	req, err := http.NewRequest(http.MethodPost, u, f)
-	if err != nil {
-		return 0, err
+		traceEnd(span, res.StatusCode, fmt.Errorf("request will be retried"))
+
+		// Concurrency slot of adaptive parallel processing is not occupied while waiting
+		if r.limiter != nil {
+			r.limiter.release()
+		}
+
+		time.Sleep(r.retry.delay(attempt, res))
+
+		if r.limiter != nil {
+			r.limiter.acquire()
+		}
 	}
+}
 
-	// Set headers
-	req.Header.Set("Content-Type", "application/octet-stream")
-	req.Header.Add("X-Redmine-API-Key", r.apiKey)
+// response checks response status and decodes response body into `out`
+func (r *Context) response(res *http.Response, body io.Reader, out interface{}, statusExpected []int) (int, error) {
 
-	// Make request
-	res, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return 0, err
+	if statusIsExpected(res.StatusCode, statusExpected) == false {
+		return res.StatusCode, responseError(res, body, statusExpected)
 	}
-	defer res.Body.Close()
 
-	dJ := json.NewDecoder(res.Body)
+	if out == nil {
+		return res.StatusCode, nil
+	}
+
+	rawConf := make(map[string]interface{})
+
+	if err := json.NewDecoder(body).Decode(&rawConf); err != nil {
 
-	if res.StatusCode != statusExpected {
-		if err := dJ.Decode(&er); err != nil {
-			er.Errors = append(er.Errors, fmt.Sprintf("json decode error: %v", err))
+		// Some of expected statuses may be returned without body (e.g. `200` instead of `201`)
+		if err == io.EOF {
+			return res.StatusCode, nil
 		}
-		er.Errors = append(er.Errors, fmt.Sprintf("unexpected status code has been returned (expected: %d, returned: %d, url: %s, method: %s)", statusExpected, res.StatusCode, u, http.MethodPost))
 
-		err = errors.New(strings.Join(er.Errors, "\n"))
-	} else {
-		if out != nil {
-			if err := dJ.Decode(&out); err != nil {
-				return res.StatusCode, fmt.Errorf("json decode error: %v", err)
-			}
+		return res.StatusCode, fmt.Errorf("json decode error: %v", err)
+	}
+
+	// Decoder stops at the end of JSON value, so rest of limited body is read to check its size
+	if l, b := body.(*limitReader); b == true {
+		if _, err := io.Copy(ioutil.Discard, l); err != nil {
+			return res.StatusCode, err
 		}
 	}
 
-	return res.StatusCode, err
-}
+	dM, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           out,
+		TagName:          "json",
+	})
+	if err != nil {
+		return res.StatusCode, fmt.Errorf("mapstructure create decoder error: %v", err)
+	}
 
-func (r *Context) downloadFile(url string, statusExpected int) (io.ReadCloser, int, error) {
+	if err := dM.Decode(rawConf); err != nil {
+		return res.StatusCode, fmt.Errorf("mapstructure decode error: %v", err)
+	}
+
+	return res.StatusCode, nil
+}
 
-	var er errorsResult
+func (r *Context) downloadFile(url string, statusExpected ...int) (io.ReadCloser, int, error) {
 
 	// Create request
 	req, err := http.NewRequest(http.MethodGet, url, nil)
@@ -232,28 +331,153 @@ func (r *Context) downloadFile(url string, statusExpected int) (io.ReadCloser, i
 	}
 
 	// Set headers
-	req.Header.Add("X-Redmine-API-Key", r.apiKey)
+	r.headersSet(req)
+
+	span := r.traceStart(http.MethodGet, req.URL.Path)
 
 	// Make request
 	res, err := http.DefaultClient.Do(req)
 	if err != nil {
+		traceEnd(span, 0, err)
 		return nil, 0, err
 	}
 
-	if res.StatusCode != statusExpected {
-		if err := json.NewDecoder(res.Body).Decode(&er); err != nil {
-			er.Errors = append(er.Errors, fmt.Sprintf("json decode error: %v", err))
+	// Request is retried once with refreshed API key
+	if r.reauthNeeded(res, statusExpected) == true {
+
+		res.Body.Close()
+		traceEnd(span, res.StatusCode, fmt.Errorf("request will be retried with refreshed API key"))
+
+		r.headersSet(req)
+
+		span = r.traceStart(http.MethodGet, req.URL.Path)
+
+		res, err = http.DefaultClient.Do(req)
+		if err != nil {
+			traceEnd(span, 0, err)
+			return nil, 0, err
 		}
-		er.Errors = append(er.Errors, fmt.Sprintf("unexpected status code has been returned (expected: %d, returned: %d, url: %s, method: %s)", statusExpected, res.StatusCode, url, http.MethodPost))
+	}
+
+	if statusIsExpected(res.StatusCode, statusExpected) == false {
+
+		err := responseError(res, res.Body, statusExpected)
 
 		res.Body.Close()
 
-		return nil, 0, errors.New(strings.Join(er.Errors, "\n"))
+		traceEnd(span, res.StatusCode, err)
+
+		return nil, res.StatusCode, err
 	}
 
+	traceEnd(span, res.StatusCode, nil)
+
 	return res.Body, res.StatusCode, err
 }
 
+// endpointNormalize checks endpoint is an absolute HTTP(S) URL and strips trailing slashes, query string and fragment
+func endpointNormalize(endpoint string) (string, error) {
+
+	u, err := url.Parse(strings.TrimSpace(endpoint))
+	if err != nil {
+		return "", fmt.Errorf("endpoint parse error: %v", err)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("endpoint error: `%s` is not an absolute HTTP(S) URL", endpoint)
+	}
+
+	u.RawQuery = ""
+	u.ForceQuery = false
+	u.Fragment = ""
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = strings.TrimRight(u.RawPath, "/")
+
+	return u.String(), nil
+}
+
+// headersSet sets common headers for requests to Redmine
+func (r *Context) headersSet(req *http.Request) {
+
+	req.Header.Set("X-Redmine-API-Key", r.apiKeyGet())
+
+	if r.switchUser != "" {
+		req.Header.Set("X-Redmine-Switch-User", r.switchUser)
+	}
+
+	if r.language != "" {
+		req.Header.Set("Accept-Language", r.language)
+	}
+}
+
+// statusIsExpected checks status is one of expected statuses.
+// Any 2xx status is expected if `statusExpected` is empty
+func statusIsExpected(status int, statusExpected []int) bool {
+
+	if len(statusExpected) == 0 {
+		return status >= 200 && status < 300
+	}
+
+	for _, s := range statusExpected {
+		if s == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+func statusesString(statuses []int) string {
+
+	if len(statuses) == 0 {
+		return "2xx"
+	}
+
+	s := make([]string, len(statuses))
+	for i, status := range statuses {
+		s[i] = strconv.Itoa(status)
+	}
+
+	return strings.Join(s, ", ")
+}
+
+// responseReader wraps response body to be limited by the max response bytes setting
+func (r *Context) responseReader(body io.Reader) io.Reader {
+
+	if r.maxResponseBytes <= 0 {
+		return body
+	}
+
+	return &limitReader{
+		r:     io.LimitReader(body, r.maxResponseBytes+1),
+		limit: r.maxResponseBytes,
+	}
+}
+
+// limitReader returns an error if more than `limit` bytes have been read
+type limitReader struct {
+	r     io.Reader
+	limit int64
+	n     int64
+}
+
+func (l *limitReader) Read(b []byte) (int, error) {
+
+	n, err := l.r.Read(b)
+
+	l.n += int64(n)
+	if l.n > l.limit {
+		return n, fmt.Errorf("response body exceeds max response bytes limit (%d bytes)", l.limit)
+	}
+
+	return n, err
+}
+
+// urlIncludes adds includes into URL params. Includes are kept in the specified order.
+//
+// Note: all query strings within package must be built with `url.Values` and its
+// `Encode()` method, that sorts params by key. Manual concatenation of query strings
+// is not allowed to keep URLs deterministic
 func urlIncludes(urlParams *url.Values, includes []string) {
 
 	if len(includes) == 0 {
@@ -262,3 +486,97 @@ func urlIncludes(urlParams *url.Values, includes []string) {
 
 	urlParams.Add("include", strings.Join(includes, ","))
 }
+
+// SetPaginationConcurrency is used to set max number of pages requested simultaneously by methods getting all records
+// (e.g. `IssuesAllGet()`). First page is requested alone to get total count, then remaining pages are requested
+// concurrently and assembled in order. If total count has been increased meanwhile, tail is requested sequentially.
+// Values less than 2 mean sequential pagination (default).
+//
+// Note: records created or deleted during concurrent pagination may shift pages, so some records may be duplicated
+// or missed in result. Use sequential pagination if consistency matters
+func (r *Context) SetPaginationConcurrency(n int) {
+	r.paginationConcurrency = n
+}
+
+// pageGet gets single page of list with specified offset and limit.
+// Returns page items, items count, total count and response status
+type pageGet func(offset, limit int) (interface{}, int, int, int, error)
+
+// page stores single page of list
+type page struct {
+	items  interface{}
+	n      int
+	total  int
+	status int
+	err    error
+}
+
+// paginate gets all pages of list via `get` and returns its items in order, total count and last response status.
+// Pagination stops when offset reaches total count or empty page is returned (e.g. if some records have been deleted).
+// In concurrent mode `get` is called from several goroutines, so it must not modify shared state (e.g. common request)
+func (r *Context) paginate(get pageGet) ([]interface{}, int, int, error) {
+
+	var pages []interface{}
+
+	p, n, total, status, err := get(0, limitDefault)
+	if err != nil {
+		return pages, total, status, err
+	}
+
+	pages = append(pages, p)
+	offset := n
+
+	// Remaining pages are requested concurrently with page size returned by server
+	if r.paginationConcurrency > 1 && n > 0 && offset < total {
+
+		var offsets []int
+		for o := offset; o < total; o += n {
+			offsets = append(offsets, o)
+		}
+
+		results := make([]page, len(offsets))
+
+		parallel(len(offsets), r.paginationConcurrency, func(i int) {
+			p, n, t, s, err := get(offsets[i], limitDefault)
+			results[i] = page{items: p, n: n, total: t, status: s, err: err}
+		})
+
+		for i, e := range results {
+
+			if e.err != nil {
+				return pages, total, e.status, e.err
+			}
+
+			pages = append(pages, e.items)
+			status = e.status
+
+			if e.total > total {
+				total = e.total
+			}
+
+			offset = offsets[i] + e.n
+
+			if e.n == 0 {
+				return pages, total, status, nil
+			}
+		}
+	}
+
+	for n > 0 && offset < total {
+
+		p, c, t, s, err := get(offset, limitDefault)
+		if err != nil {
+			return pages, total, s, err
+		}
+
+		status = s
+		total = t
+		n = c
+
+		pages = append(pages, p)
+
+		offset += n
+	}
+
+	return pages, total, status, nil
+}
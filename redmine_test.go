@@ -1,7 +1,11 @@
 package redmine
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -17,8 +21,305 @@ func initTest(r *Context, t *testing.T) {
 		t.Fatal("Init error: undefined env var `REDMINE_API_KEY`")
 	}
 
-	r.SetEndpoint(rdmnHost)
+	if err := r.SetEndpoint(rdmnHost); err != nil {
+		t.Fatal("Init error:", err)
+	}
 	r.SetAPIKey(rdmnAPIKey)
 
 	t.Logf("Init: success")
 }
+
+func TestSetEndpoint(t *testing.T) {
+
+	var r Context
+
+	for endpoint, expected := range map[string]string{
+		"https://host":                  "https://host",
+		"https://host/":                 "https://host",
+		"https://host/redmine":          "https://host/redmine",
+		"https://host/redmine/":         "https://host/redmine",
+		"https://host:8443/redmine//":   "https://host:8443/redmine",
+		"http://host/redmine?key=x#top": "http://host/redmine",
+		" https://host/ ":               "https://host",
+	} {
+
+		if err := r.SetEndpoint(endpoint); err != nil {
+			t.Fatalf("Set endpoint error: `%s`: %v", endpoint, err)
+		}
+
+		if r.endpoint != expected {
+			t.Fatalf("Set endpoint error: incorrect endpoint for `%s` (expected: %s, got: %s)", endpoint, expected, r.endpoint)
+		}
+	}
+
+	r.SetEndpoint("https://host")
+
+	for _, endpoint := range []string{"", "host", "host/redmine", "ftp://host", "https://", "https://host:port"} {
+		if err := r.SetEndpoint(endpoint); err == nil {
+			t.Fatalf("Set endpoint error: error expected for `%s`", endpoint)
+		}
+	}
+
+	if r.endpoint != "https://host" {
+		t.Fatal("Set endpoint error: endpoint has been changed by malformed value:", r.endpoint)
+	}
+
+	t.Logf("Set endpoint: success")
+}
+
+func TestPaginateConcurrent(t *testing.T) {
+
+	var (
+		r  Context
+		mu sync.Mutex
+	)
+
+	// Server returns at most 3 records per page, one record is created during pagination
+	records := 10
+
+	get := func(offset, limit int) (interface{}, int, int, int, error) {
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		var items []int
+		for i := offset; i < records && i < offset+3; i++ {
+			items = append(items, i)
+		}
+
+		if offset == 9 {
+			records++
+		}
+
+		return items, len(items), records, http.StatusOK, nil
+	}
+
+	for _, c := range []int{0, 4} {
+
+		r.SetPaginationConcurrency(c)
+		records = 10
+
+		pages, total, _, err := r.paginate(get)
+		if err != nil {
+			t.Fatal("Paginate error:", err)
+		}
+
+		var items []int
+		for _, p := range pages {
+			items = append(items, p.([]int)...)
+		}
+
+		if total != 11 || len(items) != 11 {
+			t.Fatalf("Paginate error: incorrect result for concurrency %d (total: %d, items: %v)", c, total, items)
+		}
+
+		for i, v := range items {
+			if v != i {
+				t.Fatalf("Paginate error: incorrect items order for concurrency %d: %v", c, items)
+			}
+		}
+	}
+
+	t.Logf("Paginate concurrent: success")
+}
+
+func TestSetReadEndpoint(t *testing.T) {
+
+	var (
+		r                Context
+		mu               sync.Mutex
+		primary, replica []string
+	)
+
+	handler := func(requests *[]string) http.HandlerFunc {
+		return func(w http.ResponseWriter, req *http.Request) {
+
+			mu.Lock()
+			*requests = append(*requests, req.Method+" "+req.URL.Path)
+			mu.Unlock()
+
+			if req.Method != http.MethodGet {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"issue":{"id":1}}`))
+		}
+	}
+
+	srvPrimary := httptest.NewServer(handler(&primary))
+	defer srvPrimary.Close()
+
+	srvReplica := httptest.NewServer(handler(&replica))
+	defer srvReplica.Close()
+
+	r.SetEndpoint(srvPrimary.URL)
+
+	if err := r.SetReadEndpoint(srvReplica.URL + "/"); err != nil {
+		t.Fatal("Set read endpoint error:", err)
+	}
+
+	if err := r.SetReadEndpoint("replica"); err == nil || r.readEndpoint != srvReplica.URL {
+		t.Fatal("Set read endpoint error: incorrect endpoint has been accepted:", r.readEndpoint)
+	}
+
+	if _, s, err := r.IssueSingleGet(1, IssueSingleGetRequest{}); err != nil {
+		t.Fatal("Issue get error:", err, s)
+	}
+
+	if s, err := r.IssueUpdate(1, IssueUpdateObject{Subject: "Updated"}); err != nil {
+		t.Fatal("Issue update error:", err, s)
+	}
+
+	if s, err := r.IssueDelete(1); err != nil {
+		t.Fatal("Issue delete error:", err, s)
+	}
+
+	if len(replica) != 1 || replica[0] != "GET /issues/1.json" {
+		t.Fatal("Set read endpoint error: incorrect replica requests:", replica)
+	}
+
+	if len(primary) != 2 || primary[0] != "PUT /issues/1.json" || primary[1] != "DELETE /issues/1.json" {
+		t.Fatal("Set read endpoint error: incorrect primary requests:", primary)
+	}
+
+	// Empty value disables read endpoint
+	if err := r.SetReadEndpoint(""); err != nil {
+		t.Fatal("Set read endpoint error:", err)
+	}
+
+	if _, s, err := r.IssueSingleGet(1, IssueSingleGetRequest{}); err != nil {
+		t.Fatal("Issue get error:", err, s)
+	}
+
+	if len(replica) != 1 || len(primary) != 3 || primary[2] != "GET /issues/1.json" {
+		t.Fatal("Set read endpoint error: read request has not been sent to primary:", primary, replica)
+	}
+
+	t.Logf("Set read endpoint: success")
+}
+
+func TestSetReadEndpointStaleReplica(t *testing.T) {
+
+	var (
+		r                Context
+		mu               sync.Mutex
+		primary, replica []string
+	)
+
+	// Replica lags behind: issue is not private yet, has no watchers and wiki page does not exist
+	srvReplica := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		mu.Lock()
+		replica = append(replica, req.Method+" "+req.URL.Path)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch req.URL.Path {
+		case "/issues/1.json":
+			w.Write([]byte(`{"issue":{"id":1,"is_private":false,"watchers":[]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srvReplica.Close()
+
+	srvPrimary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		mu.Lock()
+		primary = append(primary, req.Method+" "+req.URL.Path)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch req.Method + " " + req.URL.Path {
+		case "POST /issues.json":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"issue":{"id":1}}`))
+		case "PUT /issues/1.json", "PUT /projects/test/wiki/Page.json":
+			w.WriteHeader(http.StatusNoContent)
+		case "GET /issues/1.json":
+			w.Write([]byte(`{"issue":{"id":1,"is_private":true,"watchers":[{"id":5,"name":"John Smith"}]}}`))
+		case "GET /projects/test/wiki/Page.json":
+			w.Write([]byte(`{"wiki_page":{"title":"Page","text":"text","version":2}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srvPrimary.Close()
+
+	r.SetEndpoint(srvPrimary.URL)
+	r.SetReadEndpoint(srvReplica.URL)
+
+	if s, err := r.IssueSetPrivate(1, true); err != nil {
+		t.Fatal("Issue set private error:", err, s)
+	}
+
+	_, errs, s, err := r.IssueCreateWithWatchers(IssueCreateObject{ProjectID: 1, Subject: testIssueSubject}, []int{5})
+	if err != nil || errs != nil {
+		t.Fatal("Issue create with watchers error:", err, errs, s)
+	}
+
+	p, created, s, err := r.WikiUpsert("test", "Page", "text", "")
+	if err != nil || created == true || p.Version != 2 {
+		t.Fatal("Wiki upsert error:", err, s, p)
+	}
+
+	if len(replica) != 0 {
+		t.Fatal("Set read endpoint error: reads after writes have been sent to replica:", replica)
+	}
+
+	for _, e := range primary {
+		if e == "POST /issues/1/watchers.json" {
+			t.Fatal("Set read endpoint error: watcher has been added by stale data:", primary)
+		}
+	}
+
+	// Other reads are still sent to replica
+	if _, s, err := r.IssueSingleGet(1, IssueSingleGetRequest{}); err != nil || len(replica) != 1 {
+		t.Fatal("Set read endpoint error: read has not been sent to replica:", err, s, replica)
+	}
+
+	t.Logf("Set read endpoint with stale replica: success")
+}
+
+func TestSetMaxResponseBytes(t *testing.T) {
+
+	var r Context
+
+	body := `{"issue":{"id":1,"subject":"` + testIssueSubject + `"}}`
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+
+	// Body exactly at the limit
+	r.SetMaxResponseBytes(int64(len(body)))
+
+	i, s, err := r.IssueSingleGet(1, IssueSingleGetRequest{})
+	if err != nil || i.Subject != testIssueSubject {
+		t.Fatal("Max response bytes error: response within limit has been rejected:", err, s)
+	}
+
+	// Body exceeds the limit
+	r.SetMaxResponseBytes(int64(len(body) - 1))
+
+	if _, s, err := r.IssueSingleGet(1, IssueSingleGetRequest{}); err == nil || strings.Contains(err.Error(), "max response bytes") == false {
+		t.Fatal("Max response bytes error: limit exceeded error expected:", err, s)
+	}
+
+	// Zero value disables the limit
+	r.SetMaxResponseBytes(0)
+
+	if _, s, err := r.IssueSingleGet(1, IssueSingleGetRequest{}); err != nil {
+		t.Fatal("Max response bytes error: unlimited response has been rejected:", err, s)
+	}
+
+	t.Logf("Max response bytes: success")
+}
@@ -0,0 +1,147 @@
+package redmine
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	retryMaxDelayDefault = 30 * time.Second
+)
+
+// RetryPolicy describes how failed requests to Redmine API are retried
+type RetryPolicy struct {
+
+	// MaxAttempts is a max number of attempts for every request (including first one).
+	// Values less than 2 disable retries
+	MaxAttempts int
+
+	// Statuses is a list of response statuses to retry requests on.
+	// If not specified `429`, `502`, `503` and `504` are used.
+	//
+	// POST requests are not idempotent (e.g. issue create or file upload may be committed by server
+	// even if proxy responded with `502` or `504`), so they are retried only on `429` and `503` statuses
+	// from this list. Use `RetryDecider` to retry them on other responses
+	Statuses []int
+
+	// Delay is a pause between attempts. It is doubled after every attempt up to `MaxDelay`,
+	// actual pause is randomized within [Delay/2, Delay] range. If server responds with `Retry-After`
+	// header its value is used instead (capped by `MaxDelay` as well)
+	Delay time.Duration
+
+	// MaxDelay is a max pause between attempts, 30 seconds if not specified
+	MaxDelay time.Duration
+
+	// RetryDecider is an optional func consulted after statuses check for responses
+	// not matched any of retry statuses. Request is retried if it returns true.
+	//
+	// Note: to make this work response body is fully buffered in memory (within max response bytes
+	// limit, see `SetMaxResponseBytes()`) before passing to decider, and request body is buffered to be replayed
+	RetryDecider func(resp *http.Response, body []byte) bool
+}
+
+var retryStatusesDefault = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// SetRetryPolicy is used to set policy for retrying failed requests. Transport errors are not retried
+func (r *Context) SetRetryPolicy(p RetryPolicy) {
+
+	if p.MaxAttempts < 2 {
+		r.retry = nil
+		return
+	}
+
+	r.retry = &p
+}
+
+// retryable checks whether request with specified response has to be retried
+func (p *RetryPolicy) retryable(res *http.Response, body []byte) bool {
+
+	statuses := p.Statuses
+	if len(statuses) == 0 {
+		statuses = retryStatusesDefault
+	}
+
+	for _, s := range statuses {
+
+		if s != res.StatusCode {
+			continue
+		}
+
+		// Statuses meaning request has not been processed
+		if res.Request.Method != http.MethodPost || s == http.StatusTooManyRequests || s == http.StatusServiceUnavailable {
+			return true
+		}
+	}
+
+	if p.RetryDecider != nil {
+		return p.RetryDecider(res, body)
+	}
+
+	return false
+}
+
+// delay returns a pause before next attempt
+func (p *RetryPolicy) delay(attempt int, res *http.Response) time.Duration {
+
+	max := p.MaxDelay
+	if max <= 0 {
+		max = retryMaxDelayDefault
+	}
+
+	if d, b := retryAfter(res); b == true {
+		if d > max {
+			return max
+		}
+		return d
+	}
+
+	d := p.Delay
+	if d <= 0 {
+		return 0
+	}
+
+	for i := 1; i < attempt && d < max; i++ {
+		d *= 2
+	}
+
+	if d > max {
+		d = max
+	}
+
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// retryAfter gets pause requested by server via `Retry-After` header (in seconds or as HTTP date)
+func retryAfter(res *http.Response) (time.Duration, bool) {
+
+	h := res.Header.Get("Retry-After")
+	if h == "" {
+		return 0, false
+	}
+
+	if s, err := strconv.Atoi(h); err == nil {
+		if s < 0 {
+			return 0, false
+		}
+		return time.Duration(s) * time.Second, true
+	}
+
+	t, err := http.ParseTime(h)
+	if err != nil {
+		return 0, false
+	}
+
+	d := time.Until(t)
+	if d < 0 {
+		d = 0
+	}
+
+	return d, true
+}
@@ -0,0 +1,179 @@
+package redmine
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestRetryDecider(t *testing.T) {
+
+	var (
+		r        Context
+		attempts int
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		attempts++
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch attempts {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"error":"locked"}`))
+		default:
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"total_count":1}`))
+		}
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+	r.SetRetryPolicy(RetryPolicy{
+		MaxAttempts: 3,
+		RetryDecider: func(resp *http.Response, body []byte) bool {
+			return bytes.Contains(body, []byte(`"locked"`))
+		},
+	})
+
+	var i issueCountResult
+
+	s, err := r.Get(&i, url.URL{Path: "/issues.json"}, http.StatusOK)
+	if err != nil {
+		t.Fatal("Retry error:", err, s)
+	}
+
+	if attempts != 3 || i.TotalCount != 1 {
+		t.Fatalf("Retry error: incorrect result (attempts: %d, total count: %d)", attempts, i.TotalCount)
+	}
+
+	// Attempts are capped
+	attempts = 0
+
+	r.SetRetryPolicy(RetryPolicy{
+		MaxAttempts: 2,
+		RetryDecider: func(resp *http.Response, body []byte) bool {
+			return true
+		},
+	})
+
+	s, err = r.Get(&i, url.URL{Path: "/issues.json"}, http.StatusOK)
+	if err != nil || attempts != 2 {
+		t.Fatalf("Retry error: attempts are not capped (attempts: %d, status: %d, err: %v)", attempts, s, err)
+	}
+
+	t.Logf("Retry decider: success")
+}
+
+func TestRetryPost(t *testing.T) {
+
+	var (
+		r        Context
+		attempts int
+		status   int
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		attempts++
+
+		if attempts == 1 {
+			w.WriteHeader(status)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+	r.SetRetryPolicy(RetryPolicy{
+		MaxAttempts: 3,
+	})
+
+	for _, e := range []struct {
+		status   int
+		attempts int
+	}{
+		{http.StatusBadGateway, 1},
+		{http.StatusGatewayTimeout, 1},
+		{http.StatusServiceUnavailable, 2},
+		{http.StatusTooManyRequests, 2},
+	} {
+
+		attempts = 0
+		status = e.status
+
+		r.Post(nil, nil, url.URL{Path: "/issues.json"}, http.StatusCreated)
+
+		if attempts != e.attempts {
+			t.Fatalf("Retry error: incorrect attempts count for POST with status %d (expected: %d, got: %d)", e.status, e.attempts, attempts)
+		}
+	}
+
+	// Idempotent requests are retried on any of default statuses
+	attempts = 0
+	status = http.StatusGatewayTimeout
+
+	r.Put(nil, nil, url.URL{Path: "/issues/1.json"}, http.StatusCreated)
+
+	if attempts != 2 {
+		t.Fatal("Retry error: PUT has not been retried, attempts:", attempts)
+	}
+
+	t.Logf("Retry POST: success")
+}
+
+func TestRetryDelay(t *testing.T) {
+
+	p := RetryPolicy{
+		MaxAttempts: 10,
+		Delay:       time.Second,
+		MaxDelay:    10 * time.Second,
+	}
+
+	res := &http.Response{
+		Header: http.Header{},
+	}
+
+	for _, e := range []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{1, 500 * time.Millisecond, time.Second},
+		{3, 2 * time.Second, 4 * time.Second},
+		{8, 5 * time.Second, 10 * time.Second},
+		{100, 5 * time.Second, 10 * time.Second},
+	} {
+		for i := 0; i < 100; i++ {
+			if d := p.delay(e.attempt, res); d < e.min || d > e.max {
+				t.Fatalf("Retry error: incorrect delay for attempt %d: %v", e.attempt, d)
+			}
+		}
+	}
+
+	for _, e := range []struct {
+		retryAfter string
+		delay      time.Duration
+	}{
+		{"3", 3 * time.Second},
+		{"120", 10 * time.Second},
+		{time.Now().Add(time.Hour).UTC().Format(http.TimeFormat), 10 * time.Second},
+	} {
+
+		res.Header.Set("Retry-After", e.retryAfter)
+
+		if d := p.delay(1, res); d != e.delay {
+			t.Fatalf("Retry error: `Retry-After: %s` has not been honored (expected: %v, got: %v)", e.retryAfter, e.delay, d)
+		}
+	}
+
+	t.Logf("Retry delay: success")
+}
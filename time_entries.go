@@ -0,0 +1,165 @@
+package redmine
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+/* Get */
+
+// TimeEntryObject struct used for time entries get operations
+type TimeEntryObject struct {
+	ID           int                    `json:"id"`
+	Project      IDName                 `json:"project"`
+	Issue        TimeEntryIssueObject   `json:"issue"`
+	User         IDName                 `json:"user"`
+	Activity     IDName                 `json:"activity"`
+	Hours        float64                `json:"hours"`
+	Comments     string                 `json:"comments"`
+	SpentOn      string                 `json:"spent_on"`
+	CustomFields []CustomFieldGetObject `json:"custom_fields"`
+	CreatedOn    string                 `json:"created_on"`
+	UpdatedOn    string                 `json:"updated_on"`
+}
+
+// TimeEntryIssueObject struct used for time entries get operations
+type TimeEntryIssueObject struct {
+	ID int `json:"id"`
+}
+
+/* Requests */
+
+// TimeEntryAllGetRequest contains data for making request to get all time entries satisfying specified filters
+type TimeEntryAllGetRequest struct {
+	Filters TimeEntryGetRequestFilters
+}
+
+// TimeEntryMultiGetRequest contains data for making request to get limited time entries count satisfying specified filters
+type TimeEntryMultiGetRequest struct {
+	Filters TimeEntryGetRequestFilters
+	Offset  int
+	Limit   int
+}
+
+// TimeEntryGetRequestFilters contains data for making time entries get request
+type TimeEntryGetRequestFilters struct {
+	ProjectID string
+	IssueID   int
+	UserID    int
+
+	// From and To define inclusive `spent_on` dates range (only dates are used, time is ignored).
+	// Zero value means range is not bounded on corresponding side
+	From time.Time
+	To   time.Time
+}
+
+/* Results */
+
+// TimeEntryResult stores time entries requests processing result
+type TimeEntryResult struct {
+	TimeEntries []TimeEntryObject `json:"time_entries"`
+	TotalCount  int               `json:"total_count"`
+	Offset      int               `json:"offset"`
+	Limit       int               `json:"limit"`
+	TotalHours  float64           `json:"-"` // sum of hours of all got entries, used only: get all time entries
+}
+
+// TimeEntryAllGet gets info for all time entries satisfying specified filters (e.g. all entries of user
+// within dates range across all projects if project is not specified) and total hours of them.
+//
+// Note: entries within projects the current user can not view time entries of are omitted,
+// so totals may differ from ones seen by other users
+//
+// see: https://www.redmine.org/projects/redmine/wiki/Rest_TimeEntries#Listing-time-entries
+func (r *Context) TimeEntryAllGet(request TimeEntryAllGetRequest) (TimeEntryResult, int, error) {
+
+	var timeEntries TimeEntryResult
+
+	m := TimeEntryMultiGetRequest{
+		Filters: request.Filters,
+	}
+
+	pages, total, status, err := r.paginate(func(offset, limit int) (interface{}, int, int, int, error) {
+
+		req := m
+		req.Offset = offset
+		req.Limit = limit
+
+		p, s, err := r.TimeEntryMultiGet(req)
+
+		return p.TimeEntries, len(p.TimeEntries), p.TotalCount, s, err
+	})
+
+	for _, p := range pages {
+		timeEntries.TimeEntries = append(timeEntries.TimeEntries, p.([]TimeEntryObject)...)
+	}
+
+	if err != nil {
+		return timeEntries, status, err
+	}
+
+	timeEntries.TotalCount = total
+	timeEntries.Limit = total
+
+	for _, e := range timeEntries.TimeEntries {
+		timeEntries.TotalHours += e.Hours
+	}
+
+	return timeEntries, status, nil
+}
+
+// TimeEntryMultiGet gets info for multiple time entries satisfying specified filters
+//
+// see: https://www.redmine.org/projects/redmine/wiki/Rest_TimeEntries#Listing-time-entries
+func (r *Context) TimeEntryMultiGet(request TimeEntryMultiGetRequest) (TimeEntryResult, int, error) {
+
+	var t TimeEntryResult
+
+	urlParams := url.Values{}
+	urlParams.Add("offset", strconv.Itoa(request.Offset))
+	urlParams.Add("limit", strconv.Itoa(request.Limit))
+
+	// Preparing filters
+	timeEntryURLFilters(&urlParams, request.Filters)
+
+	ur := url.URL{
+		Path:     "/time_entries.json",
+		RawQuery: urlParams.Encode(),
+	}
+
+	s, err := r.Get(&t, ur, http.StatusOK)
+
+	// Redmine returns empty list if offset is beyond total count
+	if err == nil && t.TimeEntries == nil {
+		t.TimeEntries = []TimeEntryObject{}
+	}
+
+	return t, s, err
+}
+
+func timeEntryURLFilters(urlParams *url.Values, filters TimeEntryGetRequestFilters) {
+
+	if len(filters.ProjectID) > 0 {
+		urlParams.Add("project_id", filters.ProjectID)
+	}
+
+	if filters.IssueID > 0 {
+		urlParams.Add("issue_id", strconv.Itoa(filters.IssueID))
+	}
+
+	if filters.UserID > 0 {
+		urlParams.Add("user_id", strconv.Itoa(filters.UserID))
+	}
+
+	// Dates range
+	switch {
+	case filters.From.IsZero() == false && filters.To.IsZero() == false:
+		urlParams.Add("spent_on", "><"+filters.From.Format(DateFormat)+"|"+filters.To.Format(DateFormat))
+	case filters.From.IsZero() == false:
+		urlParams.Add("spent_on", ">="+filters.From.Format(DateFormat))
+	case filters.To.IsZero() == false:
+		urlParams.Add("spent_on", "<="+filters.To.Format(DateFormat))
+	}
+}
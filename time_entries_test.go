@@ -0,0 +1,88 @@
+package redmine
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestTimeEntriesURLFiltersDates(t *testing.T) {
+
+	from := time.Date(2022, 7, 1, 23, 59, 0, 0, time.UTC)
+	to := time.Date(2022, 7, 31, 0, 0, 0, 0, time.UTC)
+
+	for _, e := range []struct {
+		filters  TimeEntryGetRequestFilters
+		expected string
+	}{
+		{TimeEntryGetRequestFilters{From: from, To: to}, "><2022-07-01|2022-07-31"},
+		{TimeEntryGetRequestFilters{From: from, To: from}, "><2022-07-01|2022-07-01"},
+		{TimeEntryGetRequestFilters{From: from}, ">=2022-07-01"},
+		{TimeEntryGetRequestFilters{To: to}, "<=2022-07-31"},
+		{TimeEntryGetRequestFilters{}, ""},
+	} {
+
+		urlParams := url.Values{}
+
+		timeEntryURLFilters(&urlParams, e.filters)
+
+		if urlParams.Get("spent_on") != e.expected {
+			t.Fatalf("Time entries URL filters error: incorrect `spent_on` filter (expected: %s, got: %s)", e.expected, urlParams.Get("spent_on"))
+		}
+	}
+
+	t.Logf("Time entries URL filters dates: success")
+}
+
+func TestTimeEntriesAllGetUser(t *testing.T) {
+
+	var (
+		r       Context
+		invalid []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		q := req.URL.Query()
+		if q.Get("user_id") != "5" || q.Get("spent_on") != "><2022-07-01|2022-07-31" || q.Get("project_id") != "" {
+			invalid = append(invalid, req.URL.RawQuery)
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		// Server returns less entries than requested
+		switch q.Get("offset") {
+		case "0":
+			w.Write([]byte(`{"time_entries":[{"id":1,"project":{"id":1},"hours":1.5},{"id":2,"project":{"id":2},"hours":2}],"total_count":3,"offset":0,"limit":2}`))
+		case "2":
+			w.Write([]byte(`{"time_entries":[{"id":3,"project":{"id":3},"hours":0.25}],"total_count":3,"offset":2,"limit":2}`))
+		default:
+			invalid = append(invalid, req.URL.RawQuery)
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		}
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+
+	e, s, err := r.TimeEntryAllGet(TimeEntryAllGetRequest{
+		Filters: TimeEntryGetRequestFilters{
+			UserID: 5,
+			From:   time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC),
+			To:     time.Date(2022, 7, 31, 0, 0, 0, 0, time.UTC),
+		},
+	})
+	if err != nil || len(invalid) != 0 {
+		t.Fatal("Time entries all get error:", err, s, invalid)
+	}
+
+	if len(e.TimeEntries) != 3 || e.TotalCount != 3 || e.TotalHours != 3.75 {
+		t.Fatalf("Time entries all get error: incorrect result (entries: %d, total count: %d, total hours: %v)", len(e.TimeEntries), e.TotalCount, e.TotalHours)
+	}
+
+	t.Logf("Time entries all get for user: success")
+}
@@ -0,0 +1,75 @@
+package redmine
+
+import (
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Tracer is used to trace requests to Redmine API (e.g. as an adapter for OpenTelemetry tracer).
+// Span is started before every request (every attempt if retry policy is set) and is ended
+// when response has been received and processed, so span duration is a request duration
+type Tracer interface {
+
+	// StartSpan starts span for request with specified method and path. Path is templated,
+	// i.e. IDs, project identifiers and wiki titles are replaced with placeholders
+	// (e.g. `/projects/:project_id/wiki/:title.json`) to keep cardinality low
+	StartSpan(method, path string) Span
+}
+
+// Span is a single traced request to Redmine API
+type Span interface {
+
+	// End ends span with response status (zero if response has not been received) and request error
+	End(status int, err error)
+}
+
+// SetTracer is used to set tracer for requests to Redmine API. Nil value disables tracing (default)
+func (r *Context) SetTracer(t Tracer) {
+	r.tracer = t
+}
+
+// traceStart starts span for request if tracer is set
+func (r *Context) traceStart(method, p string) Span {
+
+	if r.tracer == nil {
+		return nil
+	}
+
+	return r.tracer.StartSpan(method, tracePath(p))
+}
+
+// traceEnd ends span if it has been started
+func traceEnd(span Span, status int, err error) {
+
+	if span == nil {
+		return
+	}
+
+	span.End(status, err)
+}
+
+// tracePath replaces IDs, project identifiers and wiki titles within path with placeholders
+func tracePath(p string) string {
+
+	ext := path.Ext(p)
+
+	s := strings.Split(strings.TrimSuffix(p, ext), "/")
+	for i := 1; i < len(s); i++ {
+
+		switch {
+		case s[i-1] == "projects":
+			s[i] = ":project_id"
+		case s[i-1] == "wiki" && s[i] != "index":
+			s[i] = ":title"
+		case i > 1 && s[i-2] == "download":
+			s[i] = ":filename"
+		default:
+			if _, err := strconv.Atoi(s[i]); err == nil {
+				s[i] = ":id"
+			}
+		}
+	}
+
+	return strings.Join(s, "/") + ext
+}
@@ -0,0 +1,89 @@
+package redmine
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type testTracer struct {
+	spans []*testSpan
+}
+
+type testSpan struct {
+	method string
+	path   string
+	status int
+	ended  bool
+}
+
+func (t *testTracer) StartSpan(method, path string) Span {
+
+	s := &testSpan{
+		method: method,
+		path:   path,
+	}
+
+	t.spans = append(t.spans, s)
+
+	return s
+}
+
+func (s *testSpan) End(status int, err error) {
+	s.status = status
+	s.ended = true
+}
+
+func TestTracePath(t *testing.T) {
+
+	for _, e := range []struct {
+		path     string
+		expected string
+	}{
+		{"/issues.json", "/issues.json"},
+		{"/issues/15/watchers/3.json", "/issues/:id/watchers/:id.json"},
+		{"/projects/test-project/memberships.json", "/projects/:project_id/memberships.json"},
+		{"/projects/test/wiki/Start_page/3.json", "/projects/:project_id/wiki/:title/:id.json"},
+		{"/projects/test/wiki/index.json", "/projects/:project_id/wiki/index.json"},
+		{"/users/current.json", "/users/current.json"},
+		{"/attachments/download/7/report.pdf", "/attachments/download/:id/:filename.pdf"},
+	} {
+		if p := tracePath(e.path); p != e.expected {
+			t.Fatalf("Trace path error: incorrect path (expected: %s, got: %s)", e.expected, p)
+		}
+	}
+
+	t.Logf("Trace path: success")
+}
+
+func TestTracerSpans(t *testing.T) {
+
+	var (
+		r  Context
+		tr testTracer
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+	r.SetTracer(&tr)
+
+	_, s, err := r.IssueSingleGet(42, IssueSingleGetRequest{})
+	if IsNotFound(err) == false {
+		t.Fatal("Tracer error: not found error expected, got:", err, s)
+	}
+
+	if len(tr.spans) != 1 {
+		t.Fatal("Tracer error: incorrect spans count:", len(tr.spans))
+	}
+
+	sp := tr.spans[0]
+	if sp.ended == false || sp.method != http.MethodGet || sp.path != "/issues/:id.json" || sp.status != http.StatusNotFound {
+		t.Fatalf("Tracer error: incorrect span: %+v", *sp)
+	}
+
+	t.Logf("Tracer spans: success")
+}
@@ -1,11 +1,15 @@
 package redmine
 
 import (
+	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
 )
 
+// userPreferencesSince is Redmine version user preferences are exposed and accepted via users API since
+const userPreferencesSince = "4.1.0"
+
 // UserStatus defines user status type
 type UserStatus int
 
@@ -46,6 +50,15 @@ type UserObject struct {
 	CustomFields []CustomFieldGetObject `json:"custom_fields"`
 	Groups       []IDName               `json:"groups"`      // used only: get single user
 	Memberships  []UserMembershipObject `json:"memberships"` // used only: get single user
+	Preferences  *UserPreferencesObject `json:"pref"`        // Since 4.1.0, nil if server does not expose user preferences
+}
+
+// UserPreferencesObject struct used for users get and update operations.
+// User preferences are available since Redmine 4.1.0, older servers neither return nor accept them
+type UserPreferencesObject struct {
+	TimeZone       string `json:"time_zone,omitempty"`
+	HideMail       *bool  `json:"hide_mail,omitempty"`
+	NoSelfNotified *bool  `json:"no_self_notified,omitempty"`
 }
 
 // UserMembershipObject struct used for users get operations
@@ -87,6 +100,7 @@ type UserUpdateObject struct {
 	GeneratePassword bool                      `json:"generate_password,omitempty"`
 	SendInformation  bool                      `json:"send_information,omitempty"`
 	CustomFields     []CustomFieldUpdateObject `json:"custom_fields,omitempty"`
+	Preferences      *UserPreferencesObject    `json:"-"` // Since 4.1.0, sent within `pref` object
 }
 
 /* Requests */
@@ -113,6 +127,12 @@ type UserCurrentGetRequest struct {
 	Includes []string
 }
 
+// UsersResolveRequest contains data for making request to resolve users references
+type UsersResolveRequest struct {
+	Limit       int // max number of users to resolve, 100 by default
+	Concurrency int // max number of users requested simultaneously, default value used if zero
+}
+
 // UserGetRequestFilters contains data for making users get request
 type UserGetRequestFilters struct {
 	Status  UserStatus
@@ -141,7 +161,8 @@ type userCreate struct {
 }
 
 type userUpdate struct {
-	User UserUpdateObject `json:"user"`
+	User        UserUpdateObject       `json:"user"`
+	Preferences *UserPreferencesObject `json:"pref,omitempty"`
 }
 
 func (u UserStatus) String() string {
@@ -173,39 +194,34 @@ func (u UserNotification) String() string {
 // * Use `groupIDFilter` == 0 to disable this filter
 func (r *Context) UserAllGet(request UserAllGetRequest) (UserResult, int, error) {
 
-	var (
-		users          UserResult
-		offset, status int
-	)
+	var users UserResult
 
 	m := UserMultiGetRequest{
 		Filters: request.Filters,
-		Limit:   limitDefault,
 	}
 
-	for {
+	pages, total, status, err := r.paginate(func(offset, limit int) (interface{}, int, int, int, error) {
 
-		m.Offset = offset
+		req := m
+		req.Offset = offset
+		req.Limit = limit
 
-		u, s, err := r.UserMultiGet(m)
-		if err != nil {
-			return users, s, err
-		}
+		p, s, err := r.UserMultiGet(req)
 
-		status = s
+		return p.Users, len(p.Users), p.TotalCount, s, err
+	})
 
-		users.Users = append(users.Users, u.Users...)
-
-		if offset+u.Limit >= u.TotalCount {
-			users.TotalCount = u.TotalCount
-			users.Limit = u.TotalCount
-
-			break
-		}
+	for _, p := range pages {
+		users.Users = append(users.Users, p.([]UserObject)...)
+	}
 
-		offset += u.Limit
+	if err != nil {
+		return users, status, err
 	}
 
+	users.TotalCount = total
+	users.Limit = total
+
 	return users, status, nil
 }
 
@@ -233,6 +249,11 @@ func (r *Context) UserMultiGet(request UserMultiGetRequest) (UserResult, int, er
 
 	s, err := r.Get(&u, ur, http.StatusOK)
 
+	// Redmine returns empty list if offset is beyond total count
+	if err == nil && u.Users == nil {
+		u.Users = []UserObject{}
+	}
+
 	return u, s, err
 }
 
@@ -247,6 +268,10 @@ func (r *Context) UserSingleGet(id int, request UserSingleGetRequest) (UserObjec
 
 	var u userSingleResult
 
+	if err := r.includesValidate("user", request.Includes); err != nil {
+		return u.User, 0, err
+	}
+
 	urlParams := url.Values{}
 
 	// Preparing includes
@@ -262,6 +287,68 @@ func (r *Context) UserSingleGet(id int, request UserSingleGetRequest) (UserObjec
 	return u.User, status, err
 }
 
+// UsersResolve gets full info for users with specified IDs (e.g. from `IDName` references within issues or journals).
+// Duplicated and zero IDs are ignored, users are requested simultaneously. Users which are not visible for
+// current user (or are groups, e.g. in `assigned_to` references) are skipped, so result may contain
+// less users than requested. If number of unique IDs exceeds `request.Limit` error is returned and nothing is requested.
+// Returned status is the status of the last request
+func (r *Context) UsersResolve(ids []int, request UsersResolveRequest) (map[int]UserObject, int, error) {
+
+	type userResult struct {
+		user   UserObject
+		status int
+		err    error
+	}
+
+	limit := request.Limit
+	if limit <= 0 {
+		limit = limitDefault
+	}
+
+	var uniq []int
+
+	seen := make(map[int]bool)
+	for _, id := range ids {
+
+		if id == 0 || seen[id] == true {
+			continue
+		}
+
+		seen[id] = true
+		uniq = append(uniq, id)
+	}
+
+	if len(uniq) > limit {
+		return map[int]UserObject{}, 0, fmt.Errorf("users resolve error: %d users requested to resolve, max count is %d", len(uniq), limit)
+	}
+
+	results := make([]userResult, len(uniq))
+
+	parallel(len(uniq), request.Concurrency, func(n int) {
+		u, s, err := r.UserSingleGet(uniq[n], UserSingleGetRequest{})
+		results[n] = userResult{user: u, status: s, err: err}
+	})
+
+	users := make(map[int]UserObject)
+	status := 0
+
+	for _, e := range results {
+
+		status = e.status
+
+		if e.err != nil {
+			if IsNotFound(e.err) == true || IsForbidden(e.err) == true {
+				continue
+			}
+			return users, e.status, e.err
+		}
+
+		users[e.user.ID] = e.user
+	}
+
+	return users, status, nil
+}
+
 // UserCurrentGet gets current user info
 //
 // see: http://www.redmine.org/projects/redmine/wiki/Rest_Users#GET-2
@@ -273,6 +360,10 @@ func (r *Context) UserCurrentGet(request UserCurrentGetRequest) (UserObject, int
 
 	var u userSingleResult
 
+	if err := r.includesValidate("user", request.Includes); err != nil {
+		return u.User, 0, err
+	}
+
 	urlParams := url.Values{}
 
 	// Preparing includes
@@ -299,21 +390,28 @@ func (r *Context) UserCreate(user UserCreateObject) (UserObject, int, error) {
 		Path: "/users.json",
 	}
 
-	status, err := r.Post(userCreate{User: user}, &u, ur, http.StatusCreated)
+	status, err := r.Post(userCreate{User: user}, &u, ur, http.StatusCreated, http.StatusOK)
 
 	return u.User, status, err
 }
 
-// UserUpdate updates user with specified ID
+// UserUpdate updates user with specified ID.
+// Use `MailNotification` and `Preferences` fields to set user notification settings.
+// Older servers silently ignore preferences, so if server version is specified (see `SetServerVersion()`)
+// and it is older than 4.1.0, update with preferences is not sent and error is returned
 //
 // see: http://www.redmine.org/projects/redmine/wiki/Rest_Users#PUT
 func (r *Context) UserUpdate(id int, user UserUpdateObject) (int, error) {
 
+	if user.Preferences != nil && r.serverVersion != "" && versionCompare(r.serverVersion, userPreferencesSince) < 0 {
+		return 0, fmt.Errorf("user update error: preferences require Redmine %s or later (server version: %s)", userPreferencesSince, r.serverVersion)
+	}
+
 	ur := url.URL{
 		Path: "/users/" + strconv.Itoa(id) + ".json",
 	}
 
-	status, err := r.Put(userUpdate{User: user}, nil, ur, http.StatusNoContent)
+	status, err := r.Put(userUpdate{User: user, Preferences: user.Preferences}, nil, ur, http.StatusNoContent, http.StatusOK)
 
 	return status, err
 }
@@ -327,7 +425,7 @@ func (r *Context) UserDelete(id int) (int, error) {
 		Path: "/users/" + strconv.Itoa(id) + ".json",
 	}
 
-	status, err := r.Del(nil, nil, ur, http.StatusNoContent)
+	status, err := r.Del(nil, nil, ur, http.StatusNoContent, http.StatusOK)
 
 	return status, err
 }
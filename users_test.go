@@ -1,6 +1,11 @@
 package redmine
 
 import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
 )
 
@@ -125,3 +130,151 @@ func testUserCurrentGet(t *testing.T, r Context) {
 
 	t.Logf("Current user get: success")
 }
+
+func TestUsersResolve(t *testing.T) {
+
+	var (
+		r    Context
+		mu   sync.Mutex
+		reqs int
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		mu.Lock()
+		reqs++
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch req.URL.Path {
+		case "/users/5.json":
+			w.Write([]byte(`{"user":{"id":5,"login":"jsmith","mail":"jsmith@example.com"}}`))
+		case "/users/6.json":
+			w.Write([]byte(`{"user":{"id":6,"login":"jdoe","mail":"jdoe@example.com"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+
+	if _, _, err := r.UsersResolve([]int{5, 0, 5, 7, 8, 6}, UsersResolveRequest{Limit: 3}); err == nil || reqs != 0 {
+		t.Fatalf("Users resolve error: limit exceeding has been accepted (requests: %d)", reqs)
+	}
+
+	users, s, err := r.UsersResolve([]int{5, 0, 5, 7, 8, 6}, UsersResolveRequest{
+		Limit: 4,
+	})
+	if err != nil {
+		t.Fatal("Users resolve error:", err, s)
+	}
+
+	if reqs != 4 || len(users) != 2 || users[5].Mail != "jsmith@example.com" {
+		t.Fatalf("Users resolve error: incorrect result (requests: %d, users: %d)", reqs, len(users))
+	}
+
+	if s != http.StatusOK {
+		t.Fatalf("Users resolve error: incorrect status of the last request: %d", s)
+	}
+
+	if _, s, err := r.UsersResolve([]int{5, 8}, UsersResolveRequest{}); err != nil || s != http.StatusNotFound {
+		t.Fatalf("Users resolve error: incorrect status of the last request: %d (%v)", s, err)
+	}
+
+	o := IssueUsersObject{Users: users}
+	if o.User(IDName{ID: 6}) == nil || o.User(IDName{ID: 7}) != nil {
+		t.Fatal("Users resolve error: incorrect users references resolution")
+	}
+
+	t.Logf("Users resolve: success")
+}
+
+func TestUserPreferences(t *testing.T) {
+
+	var (
+		r      Context
+		bodies []map[string]json.RawMessage
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch req.Method + " " + req.URL.Path {
+		case "GET /users/5.json":
+			w.Write([]byte(`{"user":{"id":5,"login":"jsmith"}}`))
+		case "GET /users/6.json":
+			w.Write([]byte(`{"user":{"id":6,"login":"jdoe","pref":{"time_zone":"Moscow","hide_mail":true}}}`))
+		case "PUT /users/6.json":
+			b, _ := ioutil.ReadAll(req.Body)
+			m := make(map[string]json.RawMessage)
+			json.Unmarshal(b, &m)
+			bodies = append(bodies, m)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+
+	u, s, err := r.UserSingleGet(5, UserSingleGetRequest{})
+	if err != nil {
+		t.Fatal("User get error:", err, s)
+	}
+	if u.Preferences != nil {
+		t.Fatal("User preferences error: preferences are not nil for server without them")
+	}
+
+	u, s, err = r.UserSingleGet(6, UserSingleGetRequest{})
+	if err != nil {
+		t.Fatal("User get error:", err, s)
+	}
+	if u.Preferences == nil || u.Preferences.TimeZone != "Moscow" || u.Preferences.HideMail == nil || *u.Preferences.HideMail != true {
+		t.Fatalf("User preferences error: incorrect preferences: %+v", u.Preferences)
+	}
+
+	hide := false
+	update := UserUpdateObject{
+		MailNotification: UserNotificationOnlyMyEvents.String(),
+		Preferences: &UserPreferencesObject{
+			TimeZone: "UTC",
+			HideMail: &hide,
+		},
+	}
+
+	if err := r.SetServerVersion("4.0.7"); err != nil {
+		t.Fatal("Set server version error:", err)
+	}
+
+	if _, err := r.UserUpdate(6, update); err == nil || len(bodies) != 0 {
+		t.Fatal("User preferences error: preferences have been sent to older server:", err, len(bodies))
+	}
+
+	// Update without preferences is sent to older server as usual
+	if s, err := r.UserUpdate(6, UserUpdateObject{MailNotification: update.MailNotification}); err != nil || len(bodies) != 1 {
+		t.Fatal("User update error:", err, s)
+	}
+	if _, b := bodies[0]["pref"]; b == true {
+		t.Fatal("User preferences error: empty preferences have been sent")
+	}
+
+	if err := r.SetServerVersion("4.1.0"); err != nil {
+		t.Fatal("Set server version error:", err)
+	}
+
+	if s, err := r.UserUpdate(6, update); err != nil || len(bodies) != 2 {
+		t.Fatal("User update error:", err, s)
+	}
+	if string(bodies[1]["pref"]) != `{"time_zone":"UTC","hide_mail":false}` {
+		t.Fatalf("User preferences error: incorrect preferences sent: %s", bodies[1]["pref"])
+	}
+	if string(bodies[1]["user"]) != `{"mail_notification":"only_my_events"}` {
+		t.Fatalf("User preferences error: incorrect user sent: %s", bodies[1]["user"])
+	}
+
+	t.Logf("User preferences: success")
+}
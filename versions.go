@@ -0,0 +1,292 @@
+package redmine
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// VersionStatus defines version status type
+type VersionStatus string
+
+// VersionStatus const
+const (
+	VersionStatusOpen   VersionStatus = "open"
+	VersionStatusLocked VersionStatus = "locked"
+	VersionStatusClosed VersionStatus = "closed"
+)
+
+// VersionSharing defines version sharing type
+type VersionSharing string
+
+// VersionSharing const
+const (
+	VersionSharingNone        VersionSharing = "none"
+	VersionSharingDescendants VersionSharing = "descendants"
+	VersionSharingHierarchy   VersionSharing = "hierarchy"
+	VersionSharingTree        VersionSharing = "tree"
+	VersionSharingSystem      VersionSharing = "system"
+)
+
+/* Get */
+
+// VersionObject struct used for versions get operations
+type VersionObject struct {
+	ID            int                    `json:"id"`
+	Project       IDName                 `json:"project"`
+	Name          string                 `json:"name"`
+	Description   string                 `json:"description"`
+	Status        VersionStatus          `json:"status"`
+	DueDate       string                 `json:"due_date"`
+	Sharing       VersionSharing         `json:"sharing"`
+	WikiPageTitle string                 `json:"wiki_page_title"`
+	CustomFields  []CustomFieldGetObject `json:"custom_fields"`
+	CreatedOn     string                 `json:"created_on"`
+	UpdatedOn     string                 `json:"updated_on"`
+}
+
+/* Create */
+
+// VersionCreateObject struct used for versions create operations
+type VersionCreateObject struct {
+	Name          string         `json:"name"`
+	Description   string         `json:"description,omitempty"`
+	Status        VersionStatus  `json:"status,omitempty"`
+	DueDate       string         `json:"due_date,omitempty"`
+	Sharing       VersionSharing `json:"sharing,omitempty"`
+	WikiPageTitle string         `json:"wiki_page_title,omitempty"`
+}
+
+/* Update */
+
+// VersionUpdateObject struct used for versions update operations
+type VersionUpdateObject struct {
+	Name          string         `json:"name,omitempty"`
+	Description   string         `json:"description,omitempty"`
+	Status        VersionStatus  `json:"status,omitempty"`
+	DueDate       string         `json:"due_date,omitempty"`
+	Sharing       VersionSharing `json:"sharing,omitempty"`
+	WikiPageTitle string         `json:"wiki_page_title,omitempty"`
+}
+
+/* Results */
+
+// VersionProgressResult stores version progress computation result
+type VersionProgressResult struct {
+	IssuesCount       int     // issues assigned to version and visible for current user
+	OpenIssuesCount   int     // open issues count
+	ClosedIssuesCount int     // closed issues count
+	EstimatedAverage  float64 // average estimate of issues having it (1 if no issue has estimate)
+	ClosedPercent     float64 // estimate weighted percent of closed issues (0-100)
+	CompletedPercent  float64 // estimate weighted percent done of all issues (0-100)
+}
+
+/* Internal types */
+
+type versionSingleResult struct {
+	Version VersionObject `json:"version"`
+}
+
+type versionCreate struct {
+	Version VersionCreateObject `json:"version"`
+}
+
+type versionUpdate struct {
+	Version VersionUpdateObject `json:"version"`
+}
+
+// VersionSingleGet gets single version info with specified ID
+//
+// see: https://www.redmine.org/projects/redmine/wiki/Rest_Versions#GET-2
+func (r *Context) VersionSingleGet(id int) (VersionObject, int, error) {
+
+	var v versionSingleResult
+
+	ur := url.URL{
+		Path: "/versions/" + strconv.Itoa(id) + ".json",
+	}
+
+	status, err := r.Get(&v, ur, http.StatusOK)
+
+	return v.Version, status, err
+}
+
+// VersionCreate creates new version for project with specified ID.
+// Status, sharing and due date are validated before request is sent
+//
+// see: https://www.redmine.org/projects/redmine/wiki/Rest_Versions#POST
+func (r *Context) VersionCreate(projectID string, version VersionCreateObject) (VersionObject, int, error) {
+
+	var v versionSingleResult
+
+	if err := versionValidate(version.Status, version.Sharing, version.DueDate); err != nil {
+		return v.Version, 0, err
+	}
+
+	ur := url.URL{
+		Path: "/projects/" + projectID + "/versions.json",
+	}
+
+	status, err := r.Post(versionCreate{Version: version}, &v, ur, http.StatusCreated, http.StatusOK)
+
+	return v.Version, status, err
+}
+
+// VersionUpdate updates version with specified ID.
+// Status, sharing and due date are validated before request is sent
+//
+// see: https://www.redmine.org/projects/redmine/wiki/Rest_Versions#PUT
+func (r *Context) VersionUpdate(id int, version VersionUpdateObject) (int, error) {
+
+	if err := versionValidate(version.Status, version.Sharing, version.DueDate); err != nil {
+		return 0, err
+	}
+
+	ur := url.URL{
+		Path: "/versions/" + strconv.Itoa(id) + ".json",
+	}
+
+	status, err := r.Put(versionUpdate{Version: version}, nil, ur, http.StatusNoContent, http.StatusOK)
+
+	return status, err
+}
+
+// VersionDelete deletes version with specified ID
+//
+// see: https://www.redmine.org/projects/redmine/wiki/Rest_Versions#DELETE
+func (r *Context) VersionDelete(id int) (int, error) {
+
+	ur := url.URL{
+		Path: "/versions/" + strconv.Itoa(id) + ".json",
+	}
+
+	status, err := r.Del(nil, nil, ur, http.StatusNoContent, http.StatusOK)
+
+	return status, err
+}
+
+// VersionProgressGet computes progress for version with specified ID the same way as Redmine roadmap does:
+// every issue is weighted by its total estimated hours (own estimate if total one is not provided, average estimate
+// for issues without estimate), closed issues are considered as done. Version without issues has zero progress,
+// version without open issues is considered as completed
+func (r *Context) VersionProgressGet(versionID int) (VersionProgressResult, int, error) {
+
+	var res VersionProgressResult
+
+	issues, status, err := r.IssuesAllGet(IssueAllGetRequest{
+		Filters: IssueGetRequestFilters{
+			Fields: map[string][]string{
+				"fixed_version_id": {strconv.Itoa(versionID)},
+				"status_id":        {IssueFilterAny},
+			},
+		},
+	})
+	if err != nil {
+		return res, status, err
+	}
+
+	statuses, status, err := r.IssueStatusAllGet()
+	if err != nil {
+		return res, status, err
+	}
+
+	closed := make(map[int]bool)
+	for _, s := range statuses {
+		closed[s.ID] = s.IsClosed
+	}
+
+	return versionProgress(issues.Issues, closed), status, nil
+}
+
+// versionProgress computes progress for specified version issues
+func versionProgress(issues []IssueObject, closed map[int]bool) VersionProgressResult {
+
+	var (
+		res                  VersionProgressResult
+		estimated            float64
+		estimatedCount       int
+		closedDone, openDone float64
+	)
+
+	res.IssuesCount = len(issues)
+	res.EstimatedAverage = 1
+
+	if len(issues) == 0 {
+		return res
+	}
+
+	for _, i := range issues {
+		if e := versionIssueEstimate(i); e > 0 {
+			estimated += e
+			estimatedCount++
+		}
+	}
+
+	if estimatedCount > 0 {
+		res.EstimatedAverage = estimated / float64(estimatedCount)
+	}
+
+	for _, i := range issues {
+
+		e := versionIssueEstimate(i)
+		if e <= 0 {
+			e = res.EstimatedAverage
+		}
+
+		if closed[i.Status.ID] == true {
+			res.ClosedIssuesCount++
+			closedDone += e * 100
+		} else {
+			res.OpenIssuesCount++
+			openDone += e * float64(i.DoneRatio)
+		}
+	}
+
+	total := res.EstimatedAverage * float64(len(issues))
+
+	res.ClosedPercent = closedDone / total
+
+	if res.OpenIssuesCount == 0 {
+		res.CompletedPercent = 100
+	} else {
+		res.CompletedPercent = (closedDone + openDone) / total
+	}
+
+	return res
+}
+
+// versionIssueEstimate returns issue estimate used for version progress computation
+func versionIssueEstimate(i IssueObject) float64 {
+
+	if i.TotalEstimatedHours != nil {
+		return *i.TotalEstimatedHours
+	}
+
+	return i.EstimatedHours
+}
+
+// versionValidate checks version status, sharing and due date. Empty values are not checked
+func versionValidate(status VersionStatus, sharing VersionSharing, dueDate string) error {
+
+	switch status {
+	case "", VersionStatusOpen, VersionStatusLocked, VersionStatusClosed:
+	default:
+		return fmt.Errorf("version validate error: incorrect `status` value: %s", status)
+	}
+
+	switch sharing {
+	case "", VersionSharingNone, VersionSharingDescendants, VersionSharingHierarchy, VersionSharingTree, VersionSharingSystem:
+	default:
+		return fmt.Errorf("version validate error: incorrect `sharing` value: %s", sharing)
+	}
+
+	if dueDate != "" {
+		if _, err := time.Parse(DateFormat, dueDate); err != nil {
+			return fmt.Errorf("version validate error: incorrect `due_date` format (expected: %s, got: %s)", DateFormat, dueDate)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package redmine
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestVersionProgress(t *testing.T) {
+
+	total := 6.0
+	closed := map[int]bool{5: true}
+
+	for _, e := range []struct {
+		issues    []IssueObject
+		closed    float64
+		completed float64
+	}{
+		{nil, 0, 0},
+		{[]IssueObject{{Status: IDName{ID: 5}}, {Status: IDName{ID: 5}}}, 100, 100},
+		{[]IssueObject{{Status: IDName{ID: 1}, DoneRatio: 50}, {Status: IDName{ID: 5}}}, 50, 75},
+		// Estimates: 6 (total), 2 and average 4 for issue without estimate
+		{[]IssueObject{
+			{Status: IDName{ID: 5}, EstimatedHours: 1, TotalEstimatedHours: &total},
+			{Status: IDName{ID: 1}, EstimatedHours: 2, DoneRatio: 50},
+			{Status: IDName{ID: 1}, DoneRatio: 25},
+		}, 50, 50 + 100.0/12 + 100.0/12},
+	} {
+
+		p := versionProgress(e.issues, closed)
+
+		if math.Abs(p.ClosedPercent-e.closed) > 1e-9 || math.Abs(p.CompletedPercent-e.completed) > 1e-9 {
+			t.Fatalf("Version progress error: incorrect progress (expected: %v/%v, got: %v/%v)", e.closed, e.completed, p.ClosedPercent, p.CompletedPercent)
+		}
+	}
+
+	t.Logf("Version progress: success")
+}
+
+func TestVersionCreateValidate(t *testing.T) {
+
+	var (
+		r    Context
+		reqs int
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		reqs++
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"version":{"id":1,"name":"1.0","status":"locked","sharing":"tree","due_date":"2022-07-31"}}`))
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+
+	for field, v := range map[string]VersionCreateObject{
+		"status":   {Name: "1.0", Status: "frozen"},
+		"sharing":  {Name: "1.0", Sharing: "everyone"},
+		"due_date": {Name: "1.0", DueDate: "31.07.2022"},
+	} {
+		if _, _, err := r.VersionCreate("test", v); err == nil || strings.Contains(err.Error(), "`"+field+"`") == false {
+			t.Fatalf("Version create error: incorrect `%s` error: %v", field, err)
+		}
+	}
+
+	if reqs != 0 {
+		t.Fatal("Version create error: invalid version has been sent")
+	}
+
+	v, s, err := r.VersionCreate("test", VersionCreateObject{
+		Name:    "1.0",
+		Status:  VersionStatusLocked,
+		Sharing: VersionSharingTree,
+		DueDate: "2022-07-31",
+	})
+	if err != nil || v.Status != VersionStatusLocked || v.Sharing != VersionSharingTree {
+		t.Fatal("Version create error:", err, s)
+	}
+
+	t.Logf("Version create validate: success")
+}
@@ -3,7 +3,9 @@ package redmine
 import (
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
+	"strings"
 )
 
 /* Get */
@@ -61,6 +63,30 @@ type WikiSingleGetRequest struct {
 	Includes []string
 }
 
+// WikiSearchRequest contains data for making wiki pages search within specified projects
+type WikiSearchRequest struct {
+	Query       string
+	ProjectIDs  []string
+	Concurrency int // max number of projects processed simultaneously, default value used if zero
+}
+
+/* Results */
+
+// WikiSearchObject stores wiki pages search result
+type WikiSearchObject struct {
+	ProjectID string
+	Title     string
+	Snippet   string
+}
+
+// WikiDiffResult stores fields changed between two wiki objects
+type WikiDiffResult struct {
+	Text        bool
+	Comments    bool
+	Parent      bool
+	Attachments bool
+}
+
 /* Internal types */
 
 type wikiAllResult struct {
@@ -71,6 +97,17 @@ type wikiSingleResult struct {
 	WikiPage WikiObject `json:"wiki_page"`
 }
 
+type wikiSearchResult struct {
+	Results []wikiSearchResultObject `json:"results"`
+}
+
+type wikiSearchResultObject struct {
+	Title       string `json:"title"`
+	Type        string `json:"type"`
+	URL         string `json:"url"`
+	Description string `json:"description"`
+}
+
 type wikiCreate struct {
 	WikiPage WikiCreateObject `json:"wiki_page"`
 }
@@ -79,7 +116,8 @@ type wikiUpdate struct {
 	WikiPage WikiUpdateObject `json:"wiki_page"`
 }
 
-// WikiAllGet gets info for all wikies for project with specified ID
+// WikiAllGet gets info for all wikies for project with specified ID.
+// Use `IsForbidden()` to check an error is caused by disabled wiki module
 //
 // see: https://www.redmine.org/projects/redmine/wiki/Rest_WikiPages#Getting-the-pages-list-of-a-wiki
 func (r *Context) WikiAllGet(projectID string) ([]WikiMultiObject, int, error) {
@@ -95,7 +133,9 @@ func (r *Context) WikiAllGet(projectID string) ([]WikiMultiObject, int, error) {
 	return w.WikiPages, status, err
 }
 
-// WikiSingleGet gets single wiki info by specific project ID and wiki title
+// WikiSingleGet gets single wiki info by specific project ID and wiki title.
+// Use `IsForbidden()` to check an error is caused by disabled wiki module
+// and `IsNotFound()` to check wiki page does not exist
 //
 // see: https://www.redmine.org/projects/redmine/wiki/Rest_WikiPages#Getting-a-wiki-page
 //
@@ -105,6 +145,10 @@ func (r *Context) WikiSingleGet(projectID, wikiTitle string, request WikiSingleG
 
 	var w wikiSingleResult
 
+	if err := r.includesValidate("wiki", request.Includes); err != nil {
+		return w.WikiPage, 0, err
+	}
+
 	urlParams := url.Values{}
 
 	// Preparing includes
@@ -130,6 +174,10 @@ func (r *Context) WikiSingleVersionGet(projectID, wikiTitle string, version int,
 
 	var w wikiSingleResult
 
+	if err := r.includesValidate("wiki", request.Includes); err != nil {
+		return w.WikiPage, 0, err
+	}
+
 	urlParams := url.Values{}
 
 	// Preparing includes
@@ -156,12 +204,13 @@ func (r *Context) WikiCreate(projectID, wikiTitle string, wiki WikiCreateObject)
 		Path: "/projects/" + projectID + "/wiki/" + wikiTitle + ".json",
 	}
 
-	status, err := r.Put(wikiCreate{WikiPage: wiki}, &w, ur, http.StatusCreated)
+	status, err := r.Put(wikiCreate{WikiPage: wiki}, &w, ur, http.StatusCreated, http.StatusOK)
 
 	return w.WikiPage, status, err
 }
 
-// WikiUpdate updates wiki page
+// WikiUpdate updates wiki page. If `Version` is set and page has been changed since that version,
+// `*VersionConflictError` is returned (see `IsVersionConflict()`) with current page version got from server
 //
 // see: https://www.redmine.org/projects/redmine/wiki/Rest_WikiPages#Creating-or-updating-a-wiki-page
 func (r *Context) WikiUpdate(projectID, wikiTitle string, wiki WikiUpdateObject) (int, error) {
@@ -170,11 +219,61 @@ func (r *Context) WikiUpdate(projectID, wikiTitle string, wiki WikiUpdateObject)
 		Path: "/projects/" + projectID + "/wiki/" + wikiTitle + ".json",
 	}
 
-	status, err := r.Put(wikiUpdate{WikiPage: wiki}, nil, ur, http.StatusNoContent)
+	status, err := r.Put(wikiUpdate{WikiPage: wiki}, nil, ur, http.StatusNoContent, http.StatusOK)
+	if IsConflict(err) == true {
+
+		e := &VersionConflictError{
+			Err: err,
+		}
+
+		if w, _, werr := r.readPrimary().WikiSingleGet(projectID, wikiTitle, WikiSingleGetRequest{}); werr == nil {
+			e.CurrentVersion = w.Version
+		}
+
+		return status, e
+	}
 
 	return status, err
 }
 
+// WikiUpsert creates wiki page or updates it if page already exists.
+// Returned flag is true if page has been created. Page version is not sent,
+// so existing page is overwritten regardless of its current version
+//
+// see: https://www.redmine.org/projects/redmine/wiki/Rest_WikiPages#Creating-or-updating-a-wiki-page
+func (r *Context) WikiUpsert(projectID, wikiTitle, text, comments string) (WikiObject, bool, int, error) {
+
+	var w wikiSingleResult
+
+	ur := url.URL{
+		Path: "/projects/" + projectID + "/wiki/" + wikiTitle + ".json",
+	}
+
+	// Redmine creates page on update request if it does not exist
+	status, err := r.Put(wikiUpdate{
+		WikiPage: WikiUpdateObject{
+			Text:     text,
+			Comments: comments,
+		},
+	}, &w, ur, http.StatusCreated, http.StatusNoContent, http.StatusOK)
+	if err != nil || r.dryRunEnabled() == true {
+		return w.WikiPage, false, status, err
+	}
+
+	created := status == http.StatusCreated
+
+	if w.WikiPage.Title != "" {
+		return w.WikiPage, created, status, nil
+	}
+
+	p, s, err := r.readPrimary().WikiSingleGet(projectID, wikiTitle, WikiSingleGetRequest{})
+	if err != nil {
+		return p, created, s, err
+	}
+
+	return p, created, status, nil
+}
+
 // WikiDelete deletes wiki with specified project ID and title
 //
 // see: https://www.redmine.org/projects/redmine/wiki/Rest_WikiPages#Deleting-a-wiki-page
@@ -184,7 +283,199 @@ func (r *Context) WikiDelete(projectID, wikiTitle string) (int, error) {
 		Path: "/projects/" + projectID + "/wiki/" + wikiTitle + ".json",
 	}
 
-	status, err := r.Del(nil, nil, ur, http.StatusNoContent)
+	status, err := r.Del(nil, nil, ur, http.StatusNoContent, http.StatusOK)
 
 	return status, err
 }
+
+// WikiSearch searches wiki pages matching specified query within specified projects.
+// Redmine search API (scoped to project wiki pages) is used, if it fails project wiki
+// pages index is used to match pages titles (snippets are empty in this case).
+// Projects with disabled wiki module are skipped. Only first 100 search results for
+// every project are returned
+//
+// see: https://www.redmine.org/projects/redmine/wiki/Rest_Search
+func (r *Context) WikiSearch(request WikiSearchRequest) ([]WikiSearchObject, int, error) {
+
+	type projectResult struct {
+		wikies []WikiSearchObject
+		status int
+		err    error
+	}
+
+	var (
+		wikies []WikiSearchObject
+		status int
+	)
+
+	results := make([]projectResult, len(request.ProjectIDs))
+
+	parallel(len(request.ProjectIDs), request.Concurrency, func(i int) {
+		w, s, err := r.wikiSearchProject(request.ProjectIDs[i], request.Query)
+		results[i] = projectResult{
+			wikies: w,
+			status: s,
+			err:    err,
+		}
+	})
+
+	uniq := make(map[string]bool)
+
+	for _, p := range results {
+
+		if p.err != nil {
+			return wikies, p.status, p.err
+		}
+
+		status = p.status
+
+		for _, w := range p.wikies {
+
+			k := w.ProjectID + "/" + w.Title
+			if uniq[k] == true {
+				continue
+			}
+			uniq[k] = true
+
+			wikies = append(wikies, w)
+		}
+	}
+
+	return wikies, status, nil
+}
+
+func (r *Context) wikiSearchProject(projectID, query string) ([]WikiSearchObject, int, error) {
+
+	var (
+		w      wikiSearchResult
+		wikies []WikiSearchObject
+	)
+
+	urlParams := url.Values{}
+	urlParams.Add("q", query)
+	urlParams.Add("wiki_pages", "1")
+	urlParams.Add("limit", strconv.Itoa(limitDefault))
+
+	ur := url.URL{
+		Path:     "/projects/" + projectID + "/search.json",
+		RawQuery: urlParams.Encode(),
+	}
+
+	status, err := r.Get(&w, ur, http.StatusOK)
+	if err == nil {
+		for _, e := range w.Results {
+
+			if e.Type != "wiki-page" {
+				continue
+			}
+
+			wikies = append(wikies, WikiSearchObject{
+				ProjectID: projectID,
+				Title:     wikiTitleFromURL(e.URL, e.Title),
+				Snippet:   e.Description,
+			})
+		}
+
+		return wikies, status, nil
+	}
+
+	// Fallback to wiki pages titles matching
+	pages, status, err := r.WikiAllGet(projectID)
+	if err != nil {
+		if IsForbidden(err) || IsNotFound(err) {
+			return nil, status, nil
+		}
+		return nil, status, err
+	}
+
+	q := strings.ToLower(query)
+
+	for _, p := range pages {
+		if strings.Contains(strings.ToLower(p.Title), q) {
+			wikies = append(wikies, WikiSearchObject{
+				ProjectID: projectID,
+				Title:     p.Title,
+			})
+		}
+	}
+
+	return wikies, status, nil
+}
+
+// wikiTitleFromURL gets wiki page title from its URL, specified title is used if URL can't be parsed
+func wikiTitleFromURL(u, title string) string {
+
+	p, err := url.Parse(u)
+	if err != nil {
+		return title
+	}
+
+	i := strings.LastIndex(p.Path, "/wiki/")
+	if i < 0 {
+		return title
+	}
+
+	return p.Path[i+len("/wiki/"):]
+}
+
+// Changed checks whether any field has been changed
+func (d WikiDiffResult) Changed() bool {
+	return d.Text || d.Comments || d.Parent || d.Attachments
+}
+
+// WikiDiffObjects compares two wiki objects and returns fields that differ.
+// Nil and empty parents or attachments are considered as equal,
+// attachments are compared by its IDs regardless of order
+func WikiDiffObjects(a, b WikiObject) WikiDiffResult {
+
+	return WikiDiffResult{
+		Text:        a.Text != b.Text,
+		Comments:    a.Comments != b.Comments,
+		Parent:      wikiParentTitle(a.Parent) != wikiParentTitle(b.Parent),
+		Attachments: wikiAttachmentsEqual(a.Attachments, b.Attachments) == false,
+	}
+}
+
+func wikiParentTitle(p *WikiParentObject) string {
+
+	if p == nil {
+		return ""
+	}
+
+	return p.Title
+}
+
+func wikiAttachmentsEqual(a, b *[]AttachmentObject) bool {
+
+	ids := func(attachments *[]AttachmentObject) []int {
+
+		var s []int
+
+		if attachments == nil {
+			return s
+		}
+
+		for _, e := range *attachments {
+			s = append(s, e.ID)
+		}
+
+		sort.Ints(s)
+
+		return s
+	}
+
+	aIDs := ids(a)
+	bIDs := ids(b)
+
+	if len(aIDs) != len(bIDs) {
+		return false
+	}
+
+	for i := range aIDs {
+		if aIDs[i] != bIDs[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -1,8 +1,15 @@
 package redmine
 
 import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"strconv"
+	"sync"
 	"testing"
 )
 
@@ -168,3 +175,331 @@ func testWikiDetele(t *testing.T, r Context, projectID, wikiTitle string) {
 
 	t.Logf("Wiki delete: success")
 }
+
+func TestWikiCreateStatusOK(t *testing.T) {
+
+	var r Context
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		if req.Method != http.MethodPut || req.URL.Path != "/projects/test/wiki/"+testWikiTitle+".json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"wiki_page":{"title":"` + testWikiTitle + `","text":"` + testWikiText + `","version":1}}`))
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+
+	w, s, err := r.WikiCreate("test", testWikiTitle, WikiCreateObject{
+		Text: testWikiText,
+	})
+	if err != nil {
+		t.Fatal("Wiki create error:", err, s)
+	}
+
+	if s != http.StatusOK {
+		t.Fatal("Wiki create error: unexpected status", s)
+	}
+
+	if w.Title != testWikiTitle || w.Text != testWikiText {
+		t.Fatal("Wiki create error: incorrect wiki page")
+	}
+
+	t.Logf("Wiki create with status 200: success")
+}
+
+func TestWikiGetErrors(t *testing.T) {
+
+	var r Context
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		switch req.URL.Path {
+		case "/projects/nowiki/wiki/index.json", "/projects/nowiki/wiki/" + testWikiTitle + ".json":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+
+	_, s, err := r.WikiAllGet("nowiki")
+	if IsForbidden(err) == false || IsNotFound(err) == true {
+		t.Fatal("Wikies all get error: forbidden error expected:", err, s)
+	}
+
+	_, s, err = r.WikiSingleGet("nowiki", testWikiTitle, WikiSingleGetRequest{})
+	if IsForbidden(err) == false || IsNotFound(err) == true {
+		t.Fatal("Wiki get error: forbidden error expected:", err, s)
+	}
+
+	_, s, err = r.WikiSingleGet("test", testWikiTitle, WikiSingleGetRequest{})
+	if IsNotFound(err) == false || IsForbidden(err) == true {
+		t.Fatal("Wiki get error: not found error expected:", err, s)
+	}
+
+	_, s, err = r.WikiSingleVersionGet("test", testWikiTitle, 1, WikiSingleGetRequest{})
+	if IsNotFound(err) == false || s != http.StatusNotFound {
+		t.Fatal("Wiki version get error: not found error expected:", err, s)
+	}
+
+	t.Logf("Wiki get errors: success")
+}
+
+func TestWikiUpdateVersionConflict(t *testing.T) {
+
+	var r Context
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		if req.Method == http.MethodPut {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"wiki_page":{"title":"` + testWikiTitle + `","text":"` + testWikiTextUpdated + `","version":4}}`))
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+
+	s, err := r.WikiUpdate("test", testWikiTitle, WikiUpdateObject{
+		Text:    testWikiText,
+		Version: 2,
+	})
+	if IsVersionConflict(err) == false || IsConflict(err) == false {
+		t.Fatal("Wiki update error: version conflict error expected:", err, s)
+	}
+
+	var e *VersionConflictError
+	if errors.As(err, &e) == false || e.CurrentVersion != 4 {
+		t.Fatal("Wiki update error: incorrect current version:", err)
+	}
+
+	t.Logf("Wiki update version conflict: success")
+}
+
+func TestWikiDiffObjects(t *testing.T) {
+
+	var r Context
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch req.URL.Path {
+		case "/projects/test/wiki/" + testWikiTitle + "/1.json":
+			w.Write([]byte(`{"wiki_page":{"title":"` + testWikiTitle + `","text":"` + testWikiText + `","version":1,` +
+				`"comments":"init","attachments":[{"id":3},{"id":2}]}}`))
+		case "/projects/test/wiki/" + testWikiTitle + "/2.json":
+			w.Write([]byte(`{"wiki_page":{"title":"` + testWikiTitle + `","text":"` + testWikiText + `","version":2,` +
+				`"comments":"move","parent":{"title":"Docs"},"attachments":[{"id":2},{"id":3}]}}`))
+		case "/projects/test/wiki/" + testWikiTitle + "/3.json":
+			w.Write([]byte(`{"wiki_page":{"title":"` + testWikiTitle + `","text":"` + testWikiTextUpdated + `","version":3,` +
+				`"comments":"move","parent":{"title":"Docs"},"attachments":[]}}`))
+		case "/projects/test/wiki/" + testWikiTitle + "/4.json":
+			w.Write([]byte(`{"wiki_page":{"title":"` + testWikiTitle + `","text":"` + testWikiTextUpdated + `","version":4,` +
+				`"comments":"move","parent":{"title":"Docs"}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+
+	versions := make(map[int]WikiObject)
+
+	for v := 1; v <= 4; v++ {
+		w, s, err := r.WikiSingleVersionGet("test", testWikiTitle, v, WikiSingleGetRequest{Includes: []string{"attachments"}})
+		if err != nil {
+			t.Fatal("Wiki version get error:", err, s)
+		}
+		versions[v] = w
+	}
+
+	// Attachments order does not matter
+	if d := WikiDiffObjects(versions[1], versions[2]); d != (WikiDiffResult{Comments: true, Parent: true}) {
+		t.Fatalf("Wiki diff objects error: incorrect diff of versions 1 and 2: %+v", d)
+	}
+
+	if d := WikiDiffObjects(versions[2], versions[3]); d != (WikiDiffResult{Text: true, Attachments: true}) || d.Changed() == false {
+		t.Fatalf("Wiki diff objects error: incorrect diff of versions 2 and 3: %+v", d)
+	}
+
+	// Empty and absent attachments are equal
+	if d := WikiDiffObjects(versions[3], versions[4]); d.Changed() == true {
+		t.Fatalf("Wiki diff objects error: incorrect diff of versions 3 and 4: %+v", d)
+	}
+
+	t.Logf("Wiki diff objects: success")
+}
+
+func TestWikiSearch(t *testing.T) {
+
+	var (
+		r       Context
+		mu      sync.Mutex
+		queries []url.Values
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch req.URL.Path {
+		case "/projects/docs/search.json":
+			mu.Lock()
+			queries = append(queries, req.URL.Query())
+			mu.Unlock()
+			w.Write([]byte(`{"results":[` +
+				`{"title":"Wiki: Install Guide","type":"wiki-page","url":"http://redmine.example.com/projects/docs/wiki/Install_Guide","description":"How to install"},` +
+				`{"title":"Bug #1: Install fails","type":"issue","url":"http://redmine.example.com/issues/1","description":""},` +
+				`{"title":"Wiki: Install Guide","type":"wiki-page","url":"http://redmine.example.com/projects/docs/wiki/Install_Guide","description":"How to install"}]}`))
+		case "/projects/legacy/search.json":
+			w.WriteHeader(http.StatusNotFound)
+		case "/projects/legacy/wiki/index.json":
+			w.Write([]byte(`{"wiki_pages":[{"title":"Wiki"},{"title":"Install_Notes"}]}`))
+		case "/projects/nowiki/search.json", "/projects/nowiki/wiki/index.json":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+
+	wikies, s, err := r.WikiSearch(WikiSearchRequest{
+		Query:      "install",
+		ProjectIDs: []string{"docs", "legacy", "nowiki"},
+	})
+	if err != nil {
+		t.Fatal("Wiki search error:", err, s)
+	}
+
+	if len(queries) != 1 || queries[0].Get("q") != "install" || queries[0].Get("wiki_pages") != "1" || queries[0].Get("limit") != "100" {
+		t.Fatal("Wiki search error: incorrect search request:", queries)
+	}
+
+	expected := []WikiSearchObject{
+		{ProjectID: "docs", Title: "Install_Guide", Snippet: "How to install"},
+		{ProjectID: "legacy", Title: "Install_Notes"},
+	}
+
+	if len(wikies) != len(expected) {
+		t.Fatalf("Wiki search error: incorrect results: %+v", wikies)
+	}
+
+	for i := range expected {
+		if wikies[i] != expected[i] {
+			t.Fatalf("Wiki search error: incorrect result %d: %+v", i, wikies[i])
+		}
+	}
+
+	t.Logf("Wiki search: success")
+}
+
+func TestWikiUpsert(t *testing.T) {
+
+	var (
+		r        Context
+		mu       sync.Mutex
+		requests []string
+		bodies   []map[string]interface{}
+		exists   bool
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		requests = append(requests, req.Method+" "+req.URL.Path)
+
+		if req.URL.Path != "/projects/test/wiki/"+testWikiTitle+".json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch req.Method {
+		case http.MethodPut:
+
+			var b struct {
+				WikiPage map[string]interface{} `json:"wiki_page"`
+			}
+
+			d, _ := ioutil.ReadAll(req.Body)
+			json.Unmarshal(d, &b)
+			bodies = append(bodies, b.WikiPage)
+
+			// Redmine responds with created page and with no content on update
+			if exists == false {
+				exists = true
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"wiki_page":{"title":"` + testWikiTitle + `","text":"` + testWikiText + `","version":1}}`))
+				return
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodGet:
+			w.Write([]byte(`{"wiki_page":{"title":"` + testWikiTitle + `","text":"` + testWikiTextUpdated + `","version":2}}`))
+		}
+	}))
+	defer srv.Close()
+
+	r.SetEndpoint(srv.URL)
+
+	p, created, s, err := r.WikiUpsert("test", testWikiTitle, testWikiText, "init")
+	if err != nil || created == false || s != http.StatusCreated {
+		t.Fatal("Wiki upsert error: page has not been created:", err, s, created)
+	}
+	if p.Version != 1 || p.Text != testWikiText {
+		t.Fatalf("Wiki upsert error: incorrect created page: %+v", p)
+	}
+
+	p, created, s, err = r.WikiUpsert("test", testWikiTitle, testWikiTextUpdated, "")
+	if err != nil || created == true || s != http.StatusNoContent {
+		t.Fatal("Wiki upsert error: page has not been updated:", err, s, created)
+	}
+	if p.Version != 2 || p.Text != testWikiTextUpdated {
+		t.Fatalf("Wiki upsert error: incorrect updated page: %+v", p)
+	}
+
+	expected := []string{
+		"PUT /projects/test/wiki/" + testWikiTitle + ".json",
+		"PUT /projects/test/wiki/" + testWikiTitle + ".json",
+		"GET /projects/test/wiki/" + testWikiTitle + ".json",
+	}
+
+	if len(requests) != len(expected) {
+		t.Fatal("Wiki upsert error: incorrect requests:", requests)
+	}
+	for i := range expected {
+		if requests[i] != expected[i] {
+			t.Fatal("Wiki upsert error: incorrect requests:", requests)
+		}
+	}
+
+	// Page version is not sent, so page is overwritten
+	for _, b := range bodies {
+		if _, v := b["version"]; v == true {
+			t.Fatal("Wiki upsert error: page version has been sent:", b)
+		}
+	}
+	if bodies[0]["text"] != testWikiText || bodies[0]["comments"] != "init" || bodies[1]["text"] != testWikiTextUpdated {
+		t.Fatal("Wiki upsert error: incorrect request bodies:", bodies)
+	}
+
+	t.Logf("Wiki upsert: success")
+}